@@ -8,8 +8,11 @@ import (
 	"context"
 	"database/sql"
 	"io"
+	"reflect"
+	"time"
 
 	"unknwon.dev/norm/internal/exql"
+	"unknwon.dev/norm/schema"
 )
 
 // Name is the name of the database adapter.
@@ -32,6 +35,8 @@ type Adapter interface {
 	Executor() Executor
 	// Typer returns the typer of the adapter.
 	Typer() Typer
+	// Introspector returns the introspector of the adapter.
+	Introspector() Introspector
 	// FormatSQL returns formatted SQL from the given string.
 	FormatSQL(sql string) string
 }
@@ -61,6 +66,78 @@ type Executor interface {
 	QueryRow(ctx context.Context, stmt *exql.Statement, args ...interface{}) (*sql.Row, error)
 }
 
+// Transactional is an optional interface that may be implemented by an
+// Executor to report whether it executes queries within a transaction. Queries
+// that cannot be executed within a transaction (e.g. CREATE INDEX CONCURRENTLY)
+// are rejected when the executor reports so.
+type Transactional interface {
+	// InTransaction returns true if the executor executes queries within a
+	// transaction.
+	InTransaction() bool
+}
+
+// QueryMethod is the method of Executor that executes a query.
+type QueryMethod string
+
+const (
+	// MethodExec is Executor.Exec, whose result is a sql.Result.
+	MethodExec QueryMethod = "Exec"
+	// MethodQuery is Executor.Query, whose result is a Rows.
+	MethodQuery QueryMethod = "Query"
+	// MethodQueryRow is Executor.QueryRow, whose result is a *sql.Row.
+	MethodQueryRow QueryMethod = "QueryRow"
+	// MethodPrepare is Executor.Prepare, whose result is a *sql.Stmt. Queries and
+	// executions of the prepared statement are not intercepted.
+	MethodPrepare QueryMethod = "Prepare"
+)
+
+// QueryInfo contains information of a query that is being executed.
+type QueryInfo struct {
+	// Method is the method of Executor that executes the query, which also
+	// determines the type of the result.
+	Method QueryMethod
+	// Type is the SQL command of the statement, e.g. "SELECT" and "INSERT", or
+	// "SQL" for raw SQL.
+	Type string
+	// Table is the name of the table that the statement applies to, which is the
+	// first one when there are multiple tables. It is empty when not applicable.
+	Table string
+	// SQL is the compiled SQL query.
+	SQL string
+	// Args is the list of arguments for placeholder parameters in the query.
+	Args []interface{}
+	// InTransaction indicates whether the query is executed within a
+	// transaction.
+	InTransaction bool
+	// StartedAt is the time when the executor received the query, before calling
+	// any interceptor. Thus the duration since then, measured after the next
+	// handler returns, includes the time spent in inner interceptors. For
+	// MethodQuery, it does not include the time of reading rows.
+	StartedAt time.Time
+}
+
+// QueryHandler executes the query and returns the result, which is a
+// sql.Result, a Rows, a *sql.Row or a *sql.Stmt depending on the
+// QueryInfo.Method.
+type QueryHandler func(ctx context.Context, info QueryInfo) (result interface{}, err error)
+
+// Interceptor intercepts the execution of every query of Executor, e.g. for
+// logging, tracing and metrics:
+//
+//   func(ctx context.Context, info adapter.QueryInfo, next adapter.QueryHandler) (interface{}, error) {
+//       result, err := next(ctx, info)
+//       log.Printf("%s [%s] %v", info.SQL, time.Since(info.StartedAt), err)
+//       return result, err
+//   }
+//
+// The interceptor may call the next handler with changed QueryInfo (e.g. to
+// change arguments), or return without calling the next handler to
+// short-circuit the query, where the result must be a non-nil value of the type
+// that corresponds to the QueryInfo.Method or nil with an error, otherwise the
+// Executor returns an error. Queries of MethodQueryRow cannot be short-circuited
+// without an error, because a *sql.Row cannot be created outside database/sql.
+type Interceptor func(ctx context.Context, info QueryInfo, next QueryHandler) (result interface{}, err error)
+
 // Rows is the result of a query. Its cursor starts before the first row of the
 // result set. Use Next to advance from row to row.
 //
@@ -69,6 +146,9 @@ type Rows interface {
 	io.Closer
 	// Columns returns the column names. It returns an error if the rows are closed.
 	Columns() ([]string, error)
+	// ColumnTypes returns column information such as the database type and
+	// nullability. It returns an error if the rows are closed.
+	ColumnTypes() ([]ColumnType, error)
 	// Err returns the error, if any, that was encountered during iteration. It may
 	// be called after an explicit or implicit Close.
 	Err() error
@@ -86,6 +166,29 @@ type Rows interface {
 	// Refer to *sql.Rows.Scan for column type conversion rules:
 	// https://pkg.go.dev/database/sql#Rows.Scan
 	Scan(dest ...interface{}) error
+	// NextResultSet prepares the next result set for reading. It reports whether
+	// there is further result sets, or false if there is no further result set or
+	// if there is an error advancing to it. Err should be consulted to distinguish
+	// between the two cases.
+	//
+	// After calling NextResultSet, the Next method should always be called before
+	// scanning.
+	NextResultSet() bool
+}
+
+// ColumnType contains the metadata of a column in query results.
+//
+// This is meant to be an abstraction of the *sql.ColumnType.
+type ColumnType interface {
+	// Name returns the name or alias of the column.
+	Name() string
+	// DatabaseTypeName returns the database system name of the column type in
+	// upper case, e.g. "INT8" and "VARCHAR". An empty string is returned when the
+	// type name is not supported by the driver.
+	DatabaseTypeName() string
+	// Nullable reports whether the column may be NULL. The ok is false when the
+	// nullability is not supported by the driver.
+	Nullable() (nullable, ok bool)
 }
 
 // Typer transparently wraps types for scanning and storing values from and to
@@ -98,4 +201,24 @@ type Typer interface {
 	// Valuer tries to wrap the given type to be a `driver.Valuer`. It is a no-op
 	// (returns the original type) when the type is unrecognizable.
 	Valuer(v interface{}) interface{}
+	// ScanType returns the Go type that values of the column should be scanned
+	// into when the destination type is not specified, e.g. for maps of
+	// interface{}. It returns nil when the column type is unrecognizable.
+	ScanType(ct ColumnType) reflect.Type
+}
+
+// Introspector retrieves the structure of the database, e.g. schemas, tables,
+// columns and indexes.
+type Introspector interface {
+	// Schemas returns names of all schemas, excluding the system ones.
+	Schemas(ctx context.Context) ([]string, error)
+	// Tables returns names of all tables in the given schema.
+	Tables(ctx context.Context, schema string) ([]string, error)
+	// Table returns the table with the given name in the given schema, along with
+	// its columns, primary key, foreign keys and indexes.
+	Table(ctx context.Context, schema, name string) (*schema.Table, error)
+	// Enums returns all enum types in the given schema.
+	Enums(ctx context.Context, schema string) ([]*schema.Enum, error)
+	// Inspect returns the given schema with all of its tables and enum types.
+	Inspect(ctx context.Context, schema string) (*schema.Schema, error)
 }
@@ -15,13 +15,15 @@ import (
 )
 
 type postgresDBAdapter struct {
-	executor *postgresDBExecutor
-	typer    postgresTyper
+	executor     *postgresDBExecutor
+	typer        postgresTyper
+	introspector *postgresIntrospector
 }
 
-func newPostgresDBAdapter(db *sql.DB, t *exql.Template) *postgresDBAdapter {
+func newPostgresDBAdapter(db *sql.DB, t *exql.Template, interceptors []adapter.Interceptor) *postgresDBAdapter {
 	adp := &postgresDBAdapter{}
-	adp.executor = newPostgresDBExecutor(db, t, adp)
+	adp.executor = newPostgresDBExecutor(db, t, adp, interceptors)
+	adp.introspector = newPostgresIntrospector(adp.executor)
 	return adp
 }
 
@@ -37,6 +39,10 @@ func (adp *postgresDBAdapter) Typer() adapter.Typer {
 	return adp.typer
 }
 
+func (adp *postgresDBAdapter) Introspector() adapter.Introspector {
+	return adp.introspector
+}
+
 func formatSQL(sql string) string {
 	var buf bytes.Buffer
 	j := 1
@@ -60,20 +66,22 @@ type postgresDBExecutor struct {
 	*iadapter.BaseDBExecutor
 }
 
-func newPostgresDBExecutor(db *sql.DB, t *exql.Template, adapter adapter.Adapter) *postgresDBExecutor {
+func newPostgresDBExecutor(db *sql.DB, t *exql.Template, adapter adapter.Adapter, interceptors []adapter.Interceptor) *postgresDBExecutor {
 	return &postgresDBExecutor{
-		BaseDBExecutor: iadapter.NewBaseDBExecutor(db, t, adapter),
+		BaseDBExecutor: iadapter.NewBaseDBExecutor(db, t, adapter, interceptors),
 	}
 }
 
 type postgresTxAdapter struct {
-	executor *postgresTxExecutor
-	typer    postgresTyper
+	executor     *postgresTxExecutor
+	typer        postgresTyper
+	introspector *postgresIntrospector
 }
 
-func newPostgresTxAdapter(tx *sql.Tx, t *exql.Template) *postgresTxAdapter {
+func newPostgresTxAdapter(tx *sql.Tx, t *exql.Template, interceptors []adapter.Interceptor) *postgresTxAdapter {
 	adp := &postgresTxAdapter{}
-	adp.executor = newPostgresTxExecutor(tx, t, adp)
+	adp.executor = newPostgresTxExecutor(tx, t, adp, interceptors)
+	adp.introspector = newPostgresIntrospector(adp.executor)
 	return adp
 }
 
@@ -89,6 +97,10 @@ func (adp *postgresTxAdapter) Typer() adapter.Typer {
 	return adp.typer
 }
 
+func (adp *postgresTxAdapter) Introspector() adapter.Introspector {
+	return adp.introspector
+}
+
 func (adp *postgresTxAdapter) FormatSQL(sql string) string {
 	return formatSQL(sql)
 }
@@ -97,8 +109,51 @@ type postgresTxExecutor struct {
 	*iadapter.BaseTxExecutor
 }
 
-func newPostgresTxExecutor(tx *sql.Tx, t *exql.Template, adapter adapter.Adapter) *postgresTxExecutor {
+func newPostgresTxExecutor(tx *sql.Tx, t *exql.Template, adapter adapter.Adapter, interceptors []adapter.Interceptor) *postgresTxExecutor {
 	return &postgresTxExecutor{
-		BaseTxExecutor: iadapter.NewBaseTxExecutor(tx, t, adapter),
+		BaseTxExecutor: iadapter.NewBaseTxExecutor(tx, t, adapter, interceptors),
+	}
+}
+
+type postgresConnAdapter struct {
+	executor     *postgresConnExecutor
+	typer        postgresTyper
+	introspector *postgresIntrospector
+}
+
+func newPostgresConnAdapter(conn *sql.Conn, t *exql.Template, interceptors []adapter.Interceptor) *postgresConnAdapter {
+	adp := &postgresConnAdapter{}
+	adp.executor = newPostgresConnExecutor(conn, t, adp, interceptors)
+	adp.introspector = newPostgresIntrospector(adp.executor)
+	return adp
+}
+
+func (*postgresConnAdapter) Name() adapter.Name {
+	return adapter.PostgreSQL
+}
+
+func (adp *postgresConnAdapter) Executor() adapter.Executor {
+	return adp.executor
+}
+
+func (adp *postgresConnAdapter) Typer() adapter.Typer {
+	return adp.typer
+}
+
+func (adp *postgresConnAdapter) Introspector() adapter.Introspector {
+	return adp.introspector
+}
+
+func (adp *postgresConnAdapter) FormatSQL(sql string) string {
+	return formatSQL(sql)
+}
+
+type postgresConnExecutor struct {
+	*iadapter.BaseConnExecutor
+}
+
+func newPostgresConnExecutor(conn *sql.Conn, t *exql.Template, adapter adapter.Adapter, interceptors []adapter.Interceptor) *postgresConnExecutor {
+	return &postgresConnExecutor{
+		BaseConnExecutor: iadapter.NewBaseConnExecutor(conn, t, adapter, interceptors),
 	}
 }
@@ -7,8 +7,12 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/stdlib"
 	"github.com/pkg/errors"
@@ -22,8 +26,30 @@ import (
 
 // OpenOptions contains options for opening a PostgreSQL database connection.
 type OpenOptions struct {
-	// NowFunc is a function to return the current time. Default is time.Now().
+	// NowFunc is a function to return the current time, which is also used for
+	// automatic timestamps of structs. Default is norm.Now().
 	norm.NowFunc
+	// StrictMapping indicates whether to return a *norm.MappingError when any
+	// column of query results has no matching field in the destination struct.
+	StrictMapping bool
+	// Mapping contains options for mapping struct fields to columns, e.g. to use
+	// snake_case column names for fields without tags:
+	//
+	//   postgres.Open(dsn, postgres.OpenOptions{
+	//       Mapping: norm.MappingOptions{NameFunc: norm.SnakeCase},
+	//   })
+	Mapping norm.MappingOptions
+	// SoftDeleteTables is the list of tables that are soft-deletable, which have
+	// a nullable "deleted_at" timestamp column. DeleteFrom marks their rows as
+	// deleted by setting the column, and queries on them exclude deleted rows
+	// unless WithDeleted or OnlyDeleted is used.
+	SoftDeleteTables []string
+	// Scopes is the list of default conditions on columns of tables, whose values
+	// are read from the context of every query, see norm.Scope for details.
+	Scopes []norm.Scope
+	// Interceptors is the chain of interceptors of executing queries, where the
+	// first one is the outermost, see adapter.Interceptor for details.
+	Interceptors []adapter.Interceptor
 }
 
 // Open opens a PostgreSQL database connection using given DSN and options.
@@ -35,36 +61,67 @@ func Open(dsn string, opts ...OpenOptions) (norm.DB, error) {
 
 	var opt OpenOptions
 	if len(opts) > 0 {
-		opt = opts[1]
+		opt = opts[0]
 	}
 	if opt.NowFunc == nil {
 		opt.NowFunc = norm.Now
 	}
 
+	return open(stdlib.OpenDB(*config), opt)
+}
+
+// open returns the database handle of the connection pool with given options.
+func open(db *sql.DB, opt OpenOptions) (*postgresDB, error) {
 	tmpl, err := exql.DefaultTemplate()
 	if err != nil {
 		return nil, errors.Wrap(err, "get template")
 	}
 
-	db := stdlib.OpenDB(*config)
-	adp := newPostgresDBAdapter(db, tmpl)
-	return &postgresDB{
-		now:      opt.NowFunc,
-		template: tmpl,
-		driver:   newPostgresDBDriver(db),
-		adapter:  adp,
-		SQL:      sqlbuilder.New(adp, tmpl),
-	}, nil
+	builderOpts := sqlbuilder.Options{
+		StrictMapping:    opt.StrictMapping,
+		Mapper:           sqlbuilder.NewMapper(opt.Mapping),
+		NowFunc:          opt.NowFunc,
+		SoftDeleteTables: opt.SoftDeleteTables,
+		Scopes:           opt.Scopes,
+		ScanPlans:        sqlbuilder.NewScanPlanCache(),
+	}
+
+	adp := newPostgresDBAdapter(db, tmpl, opt.Interceptors)
+	pdb := &postgresDB{
+		now:          opt.NowFunc,
+		template:     tmpl,
+		builderOpts:  builderOpts,
+		driver:       newPostgresDBDriver(db),
+		adapter:      adp,
+		interceptors: opt.Interceptors,
+		beginner:     db,
+	}
+	builderOpts.DB = pdb
+	pdb.SQL = sqlbuilder.New(adp, tmpl, builderOpts)
+	return pdb, nil
 }
 
 type postgresDB struct {
-	now      norm.NowFunc
-	template *exql.Template
-	driver   *postgresDBDriver
-	adapter  *postgresDBAdapter
+	now         norm.NowFunc
+	template    *exql.Template
+	builderOpts sqlbuilder.Options
+	driver      *postgresDBDriver
+	adapter     *postgresDBAdapter
+	// interceptors is the chain of interceptors of executing queries, which is
+	// shared by transactions and pinned connections.
+	interceptors []adapter.Interceptor
+	// beginner starts transactions, which is either the connection pool or the
+	// pinned connection.
+	beginner txBeginner
 	norm.SQL
 }
 
+// txBeginner is implemented by both *sql.DB and *sql.Conn to start
+// transactions.
+type txBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
 func (db *postgresDB) Now() time.Time {
 	return db.now()
 }
@@ -81,32 +138,102 @@ func (db *postgresDB) Close() error {
 	return db.driver.Close()
 }
 
+func (db *postgresDB) Conn(ctx context.Context, fn func(conn norm.DB) error) error {
+	conn, err := db.driver.Conn(ctx)
+	if err != nil {
+		return errors.Wrap(err, "get connection")
+	}
+	defer func() { _ = conn.Close() }()
+
+	// The copy of the database handle starts transactions on the connection and
+	// shares everything else.
+	cdb := *db
+	cdb.beginner = conn
+	adp := newPostgresConnAdapter(conn, db.template, db.interceptors)
+	pc := &postgresConn{
+		postgresDB: &cdb,
+		driver:     newPostgresConnDriver(conn),
+		adapter:    adp,
+	}
+	builderOpts := db.builderOpts
+	builderOpts.DB = pc
+	cdb.SQL = sqlbuilder.New(adp, db.template, builderOpts)
+	return fn(pc)
+}
+
 func (db *postgresDB) Transaction(ctx context.Context, fn func(tx norm.DB) error, opts ...*norm.TxOptions) error {
-	var err error
-	var tx *sql.Tx
-	if len(opts) == 0 {
-		tx, err = db.driver.BeginTx(ctx, nil)
-	} else {
-		tx, err = db.driver.BeginTx(ctx,
-			&sql.TxOptions{
-				Isolation: opts[0].Isolation,
-				ReadOnly:  opts[0].ReadOnly,
-			},
-		)
+	var opt *norm.TxOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt == nil || opt.Retry == nil {
+		return db.transaction(ctx, fn, opt)
+	}
+
+	retryable := opt.Retry.Retryable
+	if retryable == nil {
+		retryable = isRetryable
+	}
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && opt.Retry.Backoff != nil {
+			timer := time.NewTimer(opt.Retry.Backoff(attempt))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return errors.Wrapf(ctx.Err(), "attempt %d", attempt)
+			case <-timer.C:
+			}
+		}
+
+		err := db.transaction(ctx, fn, opt)
+		if err == nil {
+			return nil
+		} else if attempt >= opt.Retry.MaxAttempts || !retryable(err) {
+			return errors.Wrapf(err, "attempt %d", attempt)
+		}
 	}
+}
+
+// SQLSTATE codes of errors that are retryable, see
+// https://www.postgresql.org/docs/current/errcodes-appendix.html.
+const (
+	codeSerializationFailure = "40001"
+	codeDeadlockDetected     = "40P01"
+)
+
+// isRetryable returns true if the error is a serialization failure or a
+// deadlock, which are expected to succeed when the transaction is retried.
+func isRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == codeSerializationFailure || pgErr.Code == codeDeadlockDetected
+}
+
+// transaction runs the given function in a transaction with given options, the
+// options may be nil.
+func (db *postgresDB) transaction(ctx context.Context, fn func(tx norm.DB) error, opt *norm.TxOptions) error {
+	tx, err := db.begin(ctx, opt)
 	if err != nil {
-		return errors.Wrap(err, "begin")
+		return err
 	}
+	return runTransaction(tx, fn)
+}
 
-	adp := newPostgresTxAdapter(tx, db.template)
-	err = fn(
-		&postgresTX{
-			now:     db.now,
-			driver:  newPostgresTxDriver(tx),
-			adapter: adp,
-			SQL:     sqlbuilder.New(adp, db.template),
-		},
-	)
+// runTransaction runs the given function with the transaction, then commits the
+// transaction when the function succeeds, or rolls back otherwise. The
+// transaction is also rolled back when the function panics, and the panic is
+// re-thrown afterwards.
+func runTransaction(tx norm.Tx, fn func(tx norm.DB) error) error {
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	err := fn(tx)
 	if err != nil {
 		errRollback := tx.Rollback()
 		if errRollback != nil {
@@ -122,6 +249,132 @@ func (db *postgresDB) Transaction(ctx context.Context, fn func(tx norm.DB) error
 	return nil
 }
 
+func (db *postgresDB) Begin(ctx context.Context, opts ...*norm.TxOptions) (norm.Tx, error) {
+	var opt *norm.TxOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return db.begin(ctx, opt)
+}
+
+// begin starts a transaction with given options, the options may be nil.
+func (db *postgresDB) begin(ctx context.Context, opt *norm.TxOptions) (*postgresTX, error) {
+	var txOpts *sql.TxOptions
+	if opt != nil {
+		txOpts = &sql.TxOptions{
+			Isolation: opt.Isolation,
+			ReadOnly:  opt.ReadOnly,
+		}
+	}
+	tx, err := db.beginner.BeginTx(ctx, txOpts)
+	if err != nil {
+		return nil, errors.Wrap(err, "begin")
+	}
+
+	adp := newPostgresTxAdapter(tx, db.template, db.interceptors)
+	ptx := &postgresTX{
+		ctx:     ctx,
+		tx:      tx,
+		now:     db.now,
+		driver:  newPostgresTxDriver(tx),
+		adapter: adp,
+	}
+	builderOpts := db.builderOpts
+	builderOpts.DB = ptx
+	ptx.SQL = sqlbuilder.New(adp, db.template, builderOpts)
+
+	// DEFERRABLE has no effect unless the transaction is serializable and
+	// read-only.
+	if opt != nil && opt.Deferrable && opt.Isolation == sql.LevelSerializable && opt.ReadOnly {
+		_, err = adp.Executor().Exec(ctx, exql.RawSQL("SET TRANSACTION DEFERRABLE"))
+		if err != nil {
+			_ = tx.Rollback()
+			return nil, errors.Wrap(err, "set deferrable")
+		}
+	}
+	return ptx, nil
+}
+
+func (db *postgresDB) AfterCommit(fn func(ctx context.Context)) {
+	fn(context.Background())
+}
+
+func (db *postgresDB) AfterRollback(func(ctx context.Context)) {}
+
+// txCallbackKind is the kind of outcome of a transaction that a callback is
+// called with.
+type txCallbackKind int
+
+const (
+	// onCommit is called after the transaction is committed.
+	onCommit txCallbackKind = iota
+	// onRollback is called after the transaction is rolled back.
+	onRollback
+	// onEnd is called after the transaction ends regardless of the outcome, i.e.
+	// AfterRollback functions of nested transactions that have been rolled back.
+	onEnd
+)
+
+type txCallback struct {
+	kind txCallbackKind
+	fn   func(ctx context.Context)
+}
+
+// txCallbacks is the list of functions to be called after a transaction is
+// committed or rolled back.
+type txCallbacks struct {
+	mu  sync.Mutex
+	fns []txCallback
+}
+
+func (cs *txCallbacks) add(fn func(ctx context.Context), commit bool) {
+	kind := onRollback
+	if commit {
+		kind = onCommit
+	}
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.fns = append(cs.fns, txCallback{kind: kind, fn: fn})
+}
+
+// merge appends functions of the nested transaction when it ends, where
+// released indicates whether its savepoint is released. Functions of a nested
+// transaction that is rolled back are never called after commits, but always
+// called after rollbacks.
+func (cs *txCallbacks) merge(nested *txCallbacks, released bool) {
+	nested.mu.Lock()
+	fns := nested.fns
+	nested.fns = nil
+	nested.mu.Unlock()
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	for _, c := range fns {
+		if !released {
+			if c.kind == onCommit {
+				continue
+			}
+			c.kind = onEnd
+		}
+		cs.fns = append(cs.fns, c)
+	}
+}
+
+// run calls functions in the order of registration, where committed indicates
+// whether the transaction is committed.
+func (cs *txCallbacks) run(ctx context.Context, committed bool) {
+	cs.mu.Lock()
+	fns := cs.fns
+	cs.mu.Unlock()
+
+	for _, c := range fns {
+		if c.kind == onEnd || (c.kind == onCommit) == committed {
+			c.fn(ctx)
+		}
+	}
+}
+
 type postgresDBDriver struct {
 	*iadapter.BaseDBDriver
 }
@@ -132,11 +385,53 @@ func newPostgresDBDriver(db *sql.DB) *postgresDBDriver {
 	}
 }
 
+// postgresConn is a database handle that is pinned to a single connection.
+type postgresConn struct {
+	*postgresDB
+	driver  *postgresConnDriver
+	adapter *postgresConnAdapter
+}
+
+func (c *postgresConn) Driver() norm.Driver {
+	return c.driver
+}
+
+func (c *postgresConn) Adapter() adapter.Adapter {
+	return c.adapter
+}
+
+func (c *postgresConn) Close() error {
+	return errors.New("cannot close a pinned connection")
+}
+
+func (c *postgresConn) Conn(_ context.Context, fn func(conn norm.DB) error) error {
+	return fn(c)
+}
+
+type postgresConnDriver struct {
+	*iadapter.BaseConnDriver
+}
+
+func newPostgresConnDriver(conn *sql.Conn) *postgresConnDriver {
+	return &postgresConnDriver{
+		BaseConnDriver: iadapter.NewBaseConnDriver(conn),
+	}
+}
+
 type postgresTX struct {
+	// ctx is the context that the transaction is started with, which is passed
+	// to callbacks.
+	ctx     context.Context
+	tx      *sql.Tx
 	now     norm.NowFunc
 	driver  *postgresTxDriver
 	adapter *postgresTxAdapter
 	norm.SQL
+
+	// savepoints is the number of savepoints that have been created, which is
+	// used to generate unique names of savepoints.
+	savepoints uint64
+	callbacks  txCallbacks
 }
 
 func (tx *postgresTX) Now() time.Time {
@@ -155,10 +450,144 @@ func (tx *postgresTX) Close() error {
 	return errors.New("cannot close connection within a transaction")
 }
 
-func (tx *postgresTX) Transaction(ctx context.Context, fn func(tx norm.DB) error, opts ...*norm.TxOptions) error {
+func (tx *postgresTX) Conn(_ context.Context, fn func(conn norm.DB) error) error {
 	return fn(tx)
 }
 
+// Transaction runs the given function in a nested transaction using a
+// savepoint, which is rolled back to when the function returns an error, the
+// outer transaction can then carry on. Options are not applicable to nested
+// transactions and are ignored.
+func (tx *postgresTX) Transaction(ctx context.Context, fn func(tx norm.DB) error, _ ...*norm.TxOptions) error {
+	sp, err := tx.begin(ctx)
+	if err != nil {
+		return err
+	}
+	return runTransaction(sp, fn)
+}
+
+// Begin starts a nested transaction using a savepoint. Options are not
+// applicable to nested transactions and are ignored.
+func (tx *postgresTX) Begin(ctx context.Context, _ ...*norm.TxOptions) (norm.Tx, error) {
+	return tx.begin(ctx)
+}
+
+func (tx *postgresTX) begin(ctx context.Context) (*postgresSavepoint, error) {
+	return tx.savepoint(ctx, &tx.callbacks)
+}
+
+// savepoint creates a savepoint of the transaction, whose callbacks are merged
+// into the parent ones when it is released or rolled back to.
+func (tx *postgresTX) savepoint(ctx context.Context, parent *txCallbacks) (*postgresSavepoint, error) {
+	sp := &postgresSavepoint{
+		postgresTX: tx,
+		ctx:        ctx,
+		name:       fmt.Sprintf("norm_savepoint_%d", atomic.AddUint64(&tx.savepoints, 1)),
+		parent:     parent,
+	}
+	err := sp.exec(exql.StatementSavepoint)
+	if err != nil {
+		return nil, errors.Wrap(err, "create savepoint")
+	}
+	return sp, nil
+}
+
+func (tx *postgresTX) Commit() error {
+	err := tx.tx.Commit()
+	if errors.Is(err, sql.ErrTxDone) {
+		return err
+	}
+	tx.callbacks.run(tx.ctx, err == nil)
+	return err
+}
+
+func (tx *postgresTX) Rollback() error {
+	err := tx.tx.Rollback()
+	if errors.Is(err, sql.ErrTxDone) {
+		return err
+	}
+	tx.callbacks.run(tx.ctx, false)
+	return err
+}
+
+func (tx *postgresTX) AfterCommit(fn func(ctx context.Context)) {
+	tx.callbacks.add(fn, true)
+}
+
+func (tx *postgresTX) AfterRollback(fn func(ctx context.Context)) {
+	tx.callbacks.add(fn, false)
+}
+
+// postgresSavepoint is a nested transaction using a savepoint of the outer
+// transaction.
+type postgresSavepoint struct {
+	*postgresTX
+	ctx  context.Context
+	name string
+	// done is set to 1 when the savepoint is released or rolled back to.
+	done int32
+	// parent is the callbacks of the outer transaction, which callbacks of the
+	// savepoint are merged into when it ends.
+	parent    *txCallbacks
+	callbacks txCallbacks
+}
+
+func (sp *postgresSavepoint) Conn(_ context.Context, fn func(conn norm.DB) error) error {
+	return fn(sp)
+}
+
+func (sp *postgresSavepoint) Transaction(ctx context.Context, fn func(tx norm.DB) error, _ ...*norm.TxOptions) error {
+	nested, err := sp.savepoint(ctx, &sp.callbacks)
+	if err != nil {
+		return err
+	}
+	return runTransaction(nested, fn)
+}
+
+func (sp *postgresSavepoint) Begin(ctx context.Context, _ ...*norm.TxOptions) (norm.Tx, error) {
+	return sp.savepoint(ctx, &sp.callbacks)
+}
+
+func (sp *postgresSavepoint) exec(typ exql.StatementType) error {
+	_, err := sp.adapter.Executor().Exec(sp.ctx,
+		&exql.Statement{
+			Type:      typ,
+			Savepoint: exql.Table(sp.name),
+		},
+	)
+	return err
+}
+
+func (sp *postgresSavepoint) Commit() error {
+	if !atomic.CompareAndSwapInt32(&sp.done, 0, 1) {
+		return sql.ErrTxDone
+	}
+
+	// Changes of the savepoint cannot be committed when it fails to be released,
+	// e.g. the transaction is aborted.
+	err := sp.exec(exql.StatementReleaseSavepoint)
+	sp.parent.merge(&sp.callbacks, err == nil)
+	return errors.Wrap(err, "release savepoint")
+}
+
+func (sp *postgresSavepoint) Rollback() error {
+	if !atomic.CompareAndSwapInt32(&sp.done, 0, 1) {
+		return sql.ErrTxDone
+	}
+
+	err := sp.exec(exql.StatementRollbackToSavepoint)
+	sp.parent.merge(&sp.callbacks, false)
+	return errors.Wrap(err, "rollback to savepoint")
+}
+
+func (sp *postgresSavepoint) AfterCommit(fn func(ctx context.Context)) {
+	sp.callbacks.add(fn, true)
+}
+
+func (sp *postgresSavepoint) AfterRollback(fn func(ctx context.Context)) {
+	sp.callbacks.add(fn, false)
+}
+
 type postgresTxDriver struct {
 	*iadapter.BaseTxDriver
 }
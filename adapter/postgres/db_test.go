@@ -0,0 +1,624 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"unknwon.dev/norm"
+)
+
+// fakeDriver is a driver.Connector whose connections record the statements
+// that are executed on them, e.g. "conn 1: BEGIN".
+type fakeDriver struct {
+	mu    sync.Mutex
+	conns int
+	log   []string
+	// execErr returns the error of executing the query, if any.
+	execErr func(query string) error
+	// commitErr is the error of committing transactions, if any.
+	commitErr error
+}
+
+var _ driver.Connector = (*fakeDriver)(nil)
+
+func (d *fakeDriver) Connect(context.Context) (driver.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.conns++
+	return &fakeConn{driver: d, id: d.conns}, nil
+}
+
+func (d *fakeDriver) Driver() driver.Driver {
+	return nil
+}
+
+func (d *fakeDriver) record(id int, query string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.log = append(d.log, fmt.Sprintf("conn %d: %s", id, query))
+}
+
+// statements returns the recorded statements and resets the log.
+func (d *fakeDriver) statements() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	log := d.log
+	d.log = nil
+	return log
+}
+
+type fakeConn struct {
+	driver *fakeDriver
+	id     int
+}
+
+var (
+	_ driver.ConnBeginTx    = (*fakeConn)(nil)
+	_ driver.ExecerContext  = (*fakeConn)(nil)
+	_ driver.QueryerContext = (*fakeConn)(nil)
+)
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *fakeConn) BeginTx(_ context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	query := "BEGIN"
+	if opts.Isolation != driver.IsolationLevel(sql.LevelDefault) {
+		query += " ISOLATION LEVEL " + strings.ToUpper(sql.IsolationLevel(opts.Isolation).String())
+	}
+	if opts.ReadOnly {
+		query += " READ ONLY"
+	}
+	c.driver.record(c.id, query)
+	return &fakeTx{conn: c}, nil
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	c.driver.record(c.id, query)
+	if c.driver.execErr != nil {
+		if err := c.driver.execErr(query); err != nil {
+			return nil, err
+		}
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	c.driver.record(c.id, query)
+	return fakeRows{}, nil
+}
+
+type fakeTx struct {
+	conn *fakeConn
+}
+
+func (tx *fakeTx) Commit() error {
+	tx.conn.driver.record(tx.conn.id, "COMMIT")
+	return tx.conn.driver.commitErr
+}
+
+func (tx *fakeTx) Rollback() error {
+	tx.conn.driver.record(tx.conn.id, "ROLLBACK")
+	return nil
+}
+
+// fakeRows is an empty result set.
+type fakeRows struct{}
+
+func (fakeRows) Columns() []string              { return nil }
+func (fakeRows) Close() error                   { return nil }
+func (fakeRows) Next(dest []driver.Value) error { return io.EOF }
+
+// newTestDB returns a database handle on top of the fake driver.
+func newTestDB(t *testing.T, d *fakeDriver) *postgresDB {
+	sqldb := sql.OpenDB(d)
+	t.Cleanup(func() { _ = sqldb.Close() })
+
+	db, err := open(sqldb, OpenOptions{})
+	require.NoError(t, err)
+	return db
+}
+
+func TestPostgresTX_Callbacks(t *testing.T) {
+	ctx := context.Background()
+
+	// register registers callbacks that record their calls with given name.
+	register := func(db norm.DB, calls *[]string, name string) {
+		db.AfterCommit(func(context.Context) { *calls = append(*calls, name+" committed") })
+		db.AfterRollback(func(context.Context) { *calls = append(*calls, name+" rolled back") })
+	}
+
+	t.Run("commit", func(t *testing.T) {
+		var calls []string
+		err := newTestDB(t, &fakeDriver{}).Transaction(ctx, func(tx norm.DB) error {
+			register(tx, &calls, "tx")
+			assert.Empty(t, calls)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"tx committed"}, calls)
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		var calls []string
+		err := newTestDB(t, &fakeDriver{}).Transaction(ctx, func(tx norm.DB) error {
+			register(tx, &calls, "tx")
+			return errors.New("boom")
+		})
+		assert.EqualError(t, err, "boom")
+		assert.Equal(t, []string{"tx rolled back"}, calls)
+	})
+
+	t.Run("failed commit", func(t *testing.T) {
+		var calls []string
+		d := &fakeDriver{commitErr: errors.New("boom")}
+		err := newTestDB(t, d).Transaction(ctx, func(tx norm.DB) error {
+			register(tx, &calls, "tx")
+			return nil
+		})
+		assert.EqualError(t, err, "commit: boom")
+		assert.Equal(t, []string{"tx rolled back"}, calls)
+	})
+
+	t.Run("savepoint rolled back in committed transaction", func(t *testing.T) {
+		var calls []string
+		err := newTestDB(t, &fakeDriver{}).Transaction(ctx, func(tx norm.DB) error {
+			register(tx, &calls, "tx")
+			err := tx.Transaction(ctx, func(tx norm.DB) error {
+				register(tx, &calls, "savepoint")
+				err := tx.Transaction(ctx, func(tx norm.DB) error {
+					register(tx, &calls, "released savepoint")
+					return nil
+				})
+				require.NoError(t, err)
+				return errors.New("boom")
+			})
+			assert.EqualError(t, err, "boom")
+
+			err = tx.Transaction(ctx, func(tx norm.DB) error {
+				register(tx, &calls, "other savepoint")
+				return nil
+			})
+			require.NoError(t, err)
+			assert.Empty(t, calls)
+			return nil
+		})
+		require.NoError(t, err)
+
+		want := []string{
+			"tx committed",
+			"savepoint rolled back",
+			"released savepoint rolled back",
+			"other savepoint committed",
+		}
+		assert.Equal(t, want, calls)
+	})
+
+	t.Run("savepoint released in rolled back transaction", func(t *testing.T) {
+		var calls []string
+		err := newTestDB(t, &fakeDriver{}).Transaction(ctx, func(tx norm.DB) error {
+			err := tx.Transaction(ctx, func(tx norm.DB) error {
+				register(tx, &calls, "savepoint")
+				return nil
+			})
+			require.NoError(t, err)
+			return errors.New("boom")
+		})
+		assert.EqualError(t, err, "boom")
+		assert.Equal(t, []string{"savepoint rolled back"}, calls)
+	})
+
+	t.Run("savepoint failed to release", func(t *testing.T) {
+		var calls []string
+		d := &fakeDriver{
+			execErr: func(query string) error {
+				if strings.HasPrefix(query, "RELEASE SAVEPOINT") {
+					return errors.New("aborted")
+				}
+				return nil
+			},
+		}
+		err := newTestDB(t, d).Transaction(ctx, func(tx norm.DB) error {
+			err := tx.Transaction(ctx, func(tx norm.DB) error {
+				register(tx, &calls, "savepoint")
+				return nil
+			})
+			assert.EqualError(t, err, "commit: release savepoint: aborted")
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"savepoint rolled back"}, calls)
+	})
+}
+
+func TestTransactionContext(t *testing.T) {
+	d, other := &fakeDriver{}, &fakeDriver{}
+	db, otherDB := newTestDB(t, d), newTestDB(t, other)
+
+	err := norm.TransactionContext(context.Background(), db, func(ctx context.Context) error {
+		_, err := db.Truncate("users").Exec(ctx)
+		require.NoError(t, err)
+		_, err = otherDB.Truncate("orgs").Exec(ctx)
+		require.NoError(t, err)
+
+		return norm.TransactionContext(ctx, db, func(ctx context.Context) error {
+			_, err := db.Truncate("emails").Exec(ctx)
+			return err
+		})
+	})
+	require.NoError(t, err)
+
+	want := []string{
+		"conn 1: BEGIN",
+		`conn 1: TRUNCATE TABLE "users"`,
+		`conn 1: SAVEPOINT "norm_savepoint_1"`,
+		`conn 1: TRUNCATE TABLE "emails"`,
+		`conn 1: RELEASE SAVEPOINT "norm_savepoint_1"`,
+		"conn 1: COMMIT",
+	}
+	assert.Equal(t, want, d.statements())
+	assert.Equal(t, []string{`conn 1: TRUNCATE TABLE "orgs"`}, other.statements())
+}
+
+func TestPostgresDB_Begin(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("deferrable", func(t *testing.T) {
+		tests := []struct {
+			name string
+			opt  *norm.TxOptions
+			want []string
+		}{
+			{
+				name: "serializable read-only",
+				opt:  &norm.TxOptions{Isolation: sql.LevelSerializable, ReadOnly: true, Deferrable: true},
+				want: []string{
+					"conn 1: BEGIN ISOLATION LEVEL SERIALIZABLE READ ONLY",
+					"conn 1: SET TRANSACTION DEFERRABLE",
+					"conn 1: COMMIT",
+				},
+			},
+			{
+				name: "serializable",
+				opt:  &norm.TxOptions{Isolation: sql.LevelSerializable, Deferrable: true},
+				want: []string{
+					"conn 1: BEGIN ISOLATION LEVEL SERIALIZABLE",
+					"conn 1: COMMIT",
+				},
+			},
+			{
+				name: "read-only",
+				opt:  &norm.TxOptions{ReadOnly: true, Deferrable: true},
+				want: []string{
+					"conn 1: BEGIN READ ONLY",
+					"conn 1: COMMIT",
+				},
+			},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				d := &fakeDriver{}
+				tx, err := newTestDB(t, d).Begin(ctx, test.opt)
+				require.NoError(t, err)
+				require.NoError(t, tx.Commit())
+				assert.Equal(t, test.want, d.statements())
+			})
+		}
+	})
+
+	t.Run("savepoint", func(t *testing.T) {
+		d := &fakeDriver{}
+		tx, err := newTestDB(t, d).Begin(ctx)
+		require.NoError(t, err)
+
+		sp, err := tx.Begin(ctx)
+		require.NoError(t, err)
+		assert.IsType(t, &postgresSavepoint{}, sp)
+
+		nested, err := sp.Begin(ctx)
+		require.NoError(t, err)
+		require.NoError(t, nested.Rollback())
+		require.NoError(t, sp.Commit())
+		require.NoError(t, tx.Commit())
+
+		want := []string{
+			"conn 1: BEGIN",
+			`conn 1: SAVEPOINT "norm_savepoint_1"`,
+			`conn 1: SAVEPOINT "norm_savepoint_2"`,
+			`conn 1: ROLLBACK TO SAVEPOINT "norm_savepoint_2"`,
+			`conn 1: RELEASE SAVEPOINT "norm_savepoint_1"`,
+			"conn 1: COMMIT",
+		}
+		assert.Equal(t, want, d.statements())
+	})
+
+	t.Run("done", func(t *testing.T) {
+		d := &fakeDriver{}
+		db := newTestDB(t, d)
+
+		tx, err := db.Begin(ctx)
+		require.NoError(t, err)
+		sp, err := tx.Begin(ctx)
+		require.NoError(t, err)
+
+		require.NoError(t, sp.Commit())
+		assert.Equal(t, sql.ErrTxDone, sp.Commit())
+		assert.Equal(t, sql.ErrTxDone, sp.Rollback())
+
+		require.NoError(t, tx.Commit())
+		assert.Equal(t, sql.ErrTxDone, tx.Commit())
+		assert.Equal(t, sql.ErrTxDone, tx.Rollback())
+
+		tx, err = db.Begin(ctx)
+		require.NoError(t, err)
+		require.NoError(t, tx.Rollback())
+		assert.Equal(t, sql.ErrTxDone, tx.Rollback())
+		assert.Equal(t, sql.ErrTxDone, tx.Commit())
+
+		want := []string{
+			"conn 1: BEGIN",
+			`conn 1: SAVEPOINT "norm_savepoint_1"`,
+			`conn 1: RELEASE SAVEPOINT "norm_savepoint_1"`,
+			"conn 1: COMMIT",
+			"conn 1: BEGIN",
+			"conn 1: ROLLBACK",
+		}
+		assert.Equal(t, want, d.statements())
+	})
+}
+
+func TestPostgresDB_Conn(t *testing.T) {
+	ctx := context.Background()
+
+	// inUse returns the number of connections of the pool that are in use.
+	inUse := func(db *postgresDB) int {
+		return db.beginner.(*sql.DB).Stats().InUse
+	}
+
+	t.Run("release", func(t *testing.T) {
+		db := newTestDB(t, &fakeDriver{})
+
+		err := db.Conn(ctx, func(norm.DB) error {
+			assert.Equal(t, 1, inUse(db))
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 0, inUse(db))
+
+		err = db.Conn(ctx, func(norm.DB) error {
+			return errors.New("boom")
+		})
+		assert.EqualError(t, err, "boom")
+		assert.Equal(t, 0, inUse(db))
+
+		assert.PanicsWithValue(t, "boom", func() {
+			_ = db.Conn(ctx, func(norm.DB) error {
+				panic("boom")
+			})
+		})
+		assert.Equal(t, 0, inUse(db))
+	})
+
+	t.Run("in transaction", func(t *testing.T) {
+		err := newTestDB(t, &fakeDriver{}).Transaction(ctx, func(tx norm.DB) error {
+			err := tx.Conn(ctx, func(conn norm.DB) error {
+				assert.Same(t, tx, conn)
+				return nil
+			})
+			require.NoError(t, err)
+
+			return tx.Transaction(ctx, func(sp norm.DB) error {
+				return sp.Conn(ctx, func(conn norm.DB) error {
+					assert.Same(t, sp, conn)
+					return nil
+				})
+			})
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("pinned", func(t *testing.T) {
+		d := &fakeDriver{}
+		db := newTestDB(t, d)
+
+		err := db.Conn(ctx, func(conn norm.DB) error {
+			_, err := db.Truncate("users").Exec(ctx)
+			require.NoError(t, err)
+
+			err = conn.Transaction(ctx, func(tx norm.DB) error {
+				_, err := tx.Truncate("orgs").Exec(ctx)
+				return err
+			})
+			require.NoError(t, err)
+
+			tx, err := conn.Begin(ctx)
+			require.NoError(t, err)
+			_, err = tx.Truncate("emails").Exec(ctx)
+			require.NoError(t, err)
+			return tx.Commit()
+		})
+		require.NoError(t, err)
+
+		want := []string{
+			`conn 2: TRUNCATE TABLE "users"`,
+			"conn 1: BEGIN",
+			`conn 1: TRUNCATE TABLE "orgs"`,
+			"conn 1: COMMIT",
+			"conn 1: BEGIN",
+			`conn 1: TRUNCATE TABLE "emails"`,
+			"conn 1: COMMIT",
+		}
+		assert.Equal(t, want, d.statements())
+	})
+}
+
+func TestPostgresTX_Transaction(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("unique savepoints", func(t *testing.T) {
+		d := &fakeDriver{}
+		err := newTestDB(t, d).Transaction(ctx, func(tx norm.DB) error {
+			err := tx.Transaction(ctx, func(tx norm.DB) error {
+				return tx.Transaction(ctx, func(norm.DB) error { return nil })
+			})
+			require.NoError(t, err)
+			return tx.Transaction(ctx, func(norm.DB) error { return nil })
+		})
+		require.NoError(t, err)
+
+		want := []string{
+			"conn 1: BEGIN",
+			`conn 1: SAVEPOINT "norm_savepoint_1"`,
+			`conn 1: SAVEPOINT "norm_savepoint_2"`,
+			`conn 1: RELEASE SAVEPOINT "norm_savepoint_2"`,
+			`conn 1: RELEASE SAVEPOINT "norm_savepoint_1"`,
+			`conn 1: SAVEPOINT "norm_savepoint_3"`,
+			`conn 1: RELEASE SAVEPOINT "norm_savepoint_3"`,
+			"conn 1: COMMIT",
+		}
+		assert.Equal(t, want, d.statements())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		d := &fakeDriver{}
+		err := newTestDB(t, d).Transaction(ctx, func(tx norm.DB) error {
+			_, err := tx.Truncate("users").Exec(ctx)
+			require.NoError(t, err)
+
+			err = tx.Transaction(ctx, func(tx norm.DB) error {
+				_, err := tx.Truncate("emails").Exec(ctx)
+				require.NoError(t, err)
+				return errors.New("boom")
+			})
+			assert.EqualError(t, err, "boom")
+
+			_, err = tx.Truncate("orgs").Exec(ctx)
+			return err
+		})
+		require.NoError(t, err)
+
+		want := []string{
+			"conn 1: BEGIN",
+			`conn 1: TRUNCATE TABLE "users"`,
+			`conn 1: SAVEPOINT "norm_savepoint_1"`,
+			`conn 1: TRUNCATE TABLE "emails"`,
+			`conn 1: ROLLBACK TO SAVEPOINT "norm_savepoint_1"`,
+			`conn 1: TRUNCATE TABLE "orgs"`,
+			"conn 1: COMMIT",
+		}
+		assert.Equal(t, want, d.statements())
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		d := &fakeDriver{}
+		var recovered interface{}
+		err := newTestDB(t, d).Transaction(ctx, func(tx norm.DB) error {
+			func() {
+				defer func() { recovered = recover() }()
+				_ = tx.Transaction(ctx, func(norm.DB) error {
+					panic("boom")
+				})
+			}()
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "boom", recovered)
+
+		want := []string{
+			"conn 1: BEGIN",
+			`conn 1: SAVEPOINT "norm_savepoint_1"`,
+			`conn 1: ROLLBACK TO SAVEPOINT "norm_savepoint_1"`,
+			"conn 1: COMMIT",
+		}
+		assert.Equal(t, want, d.statements())
+	})
+}
+
+func TestPostgresDB_TransactionRetry(t *testing.T) {
+	ctx := context.Background()
+
+	// run runs a transaction with the retry policy, whose function returns the
+	// given error, and returns the number of attempts and the error.
+	run := func(t *testing.T, ctx context.Context, retry *norm.RetryPolicy, fnErr error) (int, error) {
+		attempts := 0
+		err := newTestDB(t, &fakeDriver{}).Transaction(ctx, func(norm.DB) error {
+			attempts++
+			return fnErr
+		}, &norm.TxOptions{Retry: retry})
+		return attempts, err
+	}
+
+	for _, code := range []string{codeSerializationFailure, codeDeadlockDetected} {
+		t.Run(code, func(t *testing.T) {
+			pgErr := &pgconn.PgError{Severity: "ERROR", Message: "boom", Code: code}
+			attempts, err := run(t, ctx, &norm.RetryPolicy{MaxAttempts: 3}, pgErr)
+			assert.EqualError(t, err, "attempt 3: "+pgErr.Error())
+			assert.True(t, errors.Is(err, pgErr))
+			assert.Equal(t, 3, attempts)
+		})
+	}
+
+	t.Run("not retryable", func(t *testing.T) {
+		attempts, err := run(t, ctx, &norm.RetryPolicy{MaxAttempts: 3}, errors.New("boom"))
+		assert.EqualError(t, err, "attempt 1: boom")
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("custom retryable", func(t *testing.T) {
+		retry := &norm.RetryPolicy{
+			MaxAttempts: 3,
+			Retryable: func(err error) bool {
+				return err.Error() == "boom"
+			},
+		}
+		attempts, err := run(t, ctx, retry, errors.New("boom"))
+		assert.EqualError(t, err, "attempt 3: boom")
+		assert.Equal(t, 3, attempts)
+
+		pgErr := &pgconn.PgError{Code: codeSerializationFailure}
+		attempts, err = run(t, ctx, retry, pgErr)
+		assert.EqualError(t, err, "attempt 1: "+pgErr.Error())
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("cancelled backoff", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		retry := &norm.RetryPolicy{
+			MaxAttempts: 3,
+			Backoff: func(int) time.Duration {
+				cancel()
+				return time.Hour
+			},
+		}
+		attempts, err := run(t, ctx, retry, &pgconn.PgError{Code: codeDeadlockDetected})
+		assert.EqualError(t, err, "attempt 2: context canceled")
+		assert.Equal(t, 1, attempts)
+	})
+}
@@ -0,0 +1,345 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jackc/pgtype"
+	"github.com/pkg/errors"
+
+	"unknwon.dev/norm/adapter"
+	"unknwon.dev/norm/internal/exql"
+	"unknwon.dev/norm/schema"
+)
+
+var _ adapter.Introspector = (*postgresIntrospector)(nil)
+
+type postgresIntrospector struct {
+	executor adapter.Executor
+}
+
+func newPostgresIntrospector(executor adapter.Executor) *postgresIntrospector {
+	return &postgresIntrospector{
+		executor: executor,
+	}
+}
+
+// query executes the query and calls the scan function for every row.
+func (in *postgresIntrospector) query(ctx context.Context, scan func(rows adapter.Rows) error, query string, args ...interface{}) error {
+	rows, err := in.executor.Query(ctx, exql.RawSQL(query), args...)
+	if err != nil {
+		return errors.Wrap(err, "query")
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		if err = scan(rows); err != nil {
+			return errors.Wrap(err, "scan")
+		}
+	}
+	return rows.Err()
+}
+
+// textArray returns the elements of the text array.
+func textArray(a pgtype.TextArray) ([]string, error) {
+	var vs []string
+	if err := a.AssignTo(&vs); err != nil {
+		return nil, err
+	}
+	if vs == nil {
+		vs = []string{}
+	}
+	return vs, nil
+}
+
+func (in *postgresIntrospector) Schemas(ctx context.Context) ([]string, error) {
+	const query = `
+SELECT nspname
+FROM pg_catalog.pg_namespace
+WHERE nspname NOT IN ('pg_catalog', 'information_schema', 'pg_toast')
+	AND nspname NOT LIKE 'pg_temp_%'
+	AND nspname NOT LIKE 'pg_toast_temp_%'
+ORDER BY nspname
+`
+	var schemas []string
+	err := in.query(ctx, func(rows adapter.Rows) error {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		schemas = append(schemas, name)
+		return nil
+	}, query)
+	if err != nil {
+		return nil, errors.Wrap(err, "list schemas")
+	}
+	return schemas, nil
+}
+
+func (in *postgresIntrospector) Tables(ctx context.Context, schema string) ([]string, error) {
+	const query = `
+SELECT c.relname
+FROM pg_catalog.pg_class c
+JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+WHERE n.nspname = ? AND c.relkind IN ('r', 'p')
+ORDER BY c.relname
+`
+	var tables []string
+	err := in.query(ctx, func(rows adapter.Rows) error {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		tables = append(tables, name)
+		return nil
+	}, query, schema)
+	if err != nil {
+		return nil, errors.Wrap(err, "list tables")
+	}
+	return tables, nil
+}
+
+func (in *postgresIntrospector) Table(ctx context.Context, schemaName, name string) (*schema.Table, error) {
+	columns, err := in.columns(ctx, schemaName, name)
+	if err != nil {
+		return nil, errors.Wrap(err, "list columns")
+	} else if len(columns) == 0 {
+		return nil, errors.Errorf("table %q does not exist in schema %q", name, schemaName)
+	}
+
+	table := &schema.Table{
+		Schema:  schemaName,
+		Name:    name,
+		Columns: columns,
+	}
+	if err = in.constraints(ctx, table); err != nil {
+		return nil, errors.Wrap(err, "list constraints")
+	}
+
+	table.Indexes, err = in.indexes(ctx, schemaName, name)
+	if err != nil {
+		return nil, errors.Wrap(err, "list indexes")
+	}
+	return table, nil
+}
+
+func (in *postgresIntrospector) columns(ctx context.Context, schemaName, table string) ([]*schema.Column, error) {
+	const query = `
+SELECT
+	a.attname,
+	pg_catalog.format_type(a.atttypid, a.atttypmod),
+	NOT a.attnotnull,
+	pg_catalog.pg_get_expr(d.adbin, d.adrelid)
+FROM pg_catalog.pg_attribute a
+JOIN pg_catalog.pg_class c ON c.oid = a.attrelid
+JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+LEFT JOIN pg_catalog.pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+WHERE n.nspname = ? AND c.relname = ? AND c.relkind IN ('r', 'p') AND a.attnum > 0 AND NOT a.attisdropped
+ORDER BY a.attnum
+`
+	var columns []*schema.Column
+	err := in.query(ctx, func(rows adapter.Rows) error {
+		var c schema.Column
+		var def sql.NullString
+		if err := rows.Scan(&c.Name, &c.Type, &c.Nullable, &def); err != nil {
+			return err
+		}
+		if def.Valid {
+			c.Default = &def.String
+		}
+		columns = append(columns, &c)
+		return nil
+	}, query, schemaName, table)
+	if err != nil {
+		return nil, err
+	}
+	return columns, nil
+}
+
+// foreignKeyActions maps the action codes in the pg_constraint to their SQL
+// keywords.
+var foreignKeyActions = map[string]string{
+	"a": "NO ACTION",
+	"r": "RESTRICT",
+	"c": "CASCADE",
+	"n": "SET NULL",
+	"d": "SET DEFAULT",
+}
+
+func (in *postgresIntrospector) constraints(ctx context.Context, table *schema.Table) error {
+	const query = `
+SELECT
+	con.conname,
+	con.contype::text,
+	ARRAY(
+		SELECT a.attname
+		FROM unnest(con.conkey) WITH ORDINALITY AS k(attnum, ord)
+		JOIN pg_catalog.pg_attribute a ON a.attrelid = con.conrelid AND a.attnum = k.attnum
+		ORDER BY k.ord
+	)::text[],
+	COALESCE(fn.nspname, ''),
+	COALESCE(fc.relname, ''),
+	ARRAY(
+		SELECT a.attname
+		FROM unnest(con.confkey) WITH ORDINALITY AS k(attnum, ord)
+		JOIN pg_catalog.pg_attribute a ON a.attrelid = con.confrelid AND a.attnum = k.attnum
+		ORDER BY k.ord
+	)::text[],
+	con.confupdtype::text,
+	con.confdeltype::text
+FROM pg_catalog.pg_constraint con
+JOIN pg_catalog.pg_class c ON c.oid = con.conrelid
+JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+LEFT JOIN pg_catalog.pg_class fc ON fc.oid = con.confrelid
+LEFT JOIN pg_catalog.pg_namespace fn ON fn.oid = fc.relnamespace
+WHERE n.nspname = ? AND c.relname = ? AND con.contype IN ('p', 'f')
+ORDER BY con.conname
+`
+	return in.query(ctx, func(rows adapter.Rows) error {
+		var name, typ, refSchema, refTable, onUpdate, onDelete string
+		var columns, refColumns pgtype.TextArray
+		err := rows.Scan(&name, &typ, &columns, &refSchema, &refTable, &refColumns, &onUpdate, &onDelete)
+		if err != nil {
+			return err
+		}
+
+		cols, err := textArray(columns)
+		if err != nil {
+			return errors.Wrap(err, "columns")
+		}
+
+		if typ == "p" {
+			table.PrimaryKey = &schema.PrimaryKey{
+				Name:    name,
+				Columns: cols,
+			}
+			return nil
+		}
+
+		refCols, err := textArray(refColumns)
+		if err != nil {
+			return errors.Wrap(err, "referenced columns")
+		}
+		table.ForeignKeys = append(table.ForeignKeys,
+			&schema.ForeignKey{
+				Name:       name,
+				Columns:    cols,
+				RefSchema:  refSchema,
+				RefTable:   refTable,
+				RefColumns: refCols,
+				OnUpdate:   foreignKeyActions[onUpdate],
+				OnDelete:   foreignKeyActions[onDelete],
+			},
+		)
+		return nil
+	}, query, table.Schema, table.Name)
+}
+
+func (in *postgresIntrospector) indexes(ctx context.Context, schemaName, table string) ([]*schema.Index, error) {
+	const query = `
+SELECT
+	ic.relname,
+	i.indisunique,
+	i.indisprimary,
+	ARRAY(
+		SELECT pg_catalog.pg_get_indexdef(i.indexrelid, k, true)
+		FROM generate_series(1, i.indnatts) AS k
+		ORDER BY k
+	)::text[],
+	pg_catalog.pg_get_indexdef(i.indexrelid)
+FROM pg_catalog.pg_index i
+JOIN pg_catalog.pg_class ic ON ic.oid = i.indexrelid
+JOIN pg_catalog.pg_class c ON c.oid = i.indrelid
+JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+WHERE n.nspname = ? AND c.relname = ?
+ORDER BY ic.relname
+`
+	var indexes []*schema.Index
+	err := in.query(ctx, func(rows adapter.Rows) error {
+		var idx schema.Index
+		var columns pgtype.TextArray
+		if err := rows.Scan(&idx.Name, &idx.Unique, &idx.Primary, &columns, &idx.Definition); err != nil {
+			return err
+		}
+
+		var err error
+		idx.Columns, err = textArray(columns)
+		if err != nil {
+			return errors.Wrap(err, "columns")
+		}
+		indexes = append(indexes, &idx)
+		return nil
+	}, query, schemaName, table)
+	if err != nil {
+		return nil, err
+	}
+	return indexes, nil
+}
+
+func (in *postgresIntrospector) Enums(ctx context.Context, schemaName string) ([]*schema.Enum, error) {
+	const query = `
+SELECT
+	t.typname,
+	ARRAY(
+		SELECT e.enumlabel
+		FROM pg_catalog.pg_enum e
+		WHERE e.enumtypid = t.oid
+		ORDER BY e.enumsortorder
+	)::text[]
+FROM pg_catalog.pg_type t
+JOIN pg_catalog.pg_namespace n ON n.oid = t.typnamespace
+WHERE n.nspname = ? AND t.typtype = 'e'
+ORDER BY t.typname
+`
+	var enums []*schema.Enum
+	err := in.query(ctx, func(rows adapter.Rows) error {
+		e := &schema.Enum{
+			Schema: schemaName,
+		}
+		var values pgtype.TextArray
+		if err := rows.Scan(&e.Name, &values); err != nil {
+			return err
+		}
+
+		var err error
+		e.Values, err = textArray(values)
+		if err != nil {
+			return errors.Wrap(err, "values")
+		}
+		enums = append(enums, e)
+		return nil
+	}, query, schemaName)
+	if err != nil {
+		return nil, errors.Wrap(err, "list enums")
+	}
+	return enums, nil
+}
+
+func (in *postgresIntrospector) Inspect(ctx context.Context, schemaName string) (*schema.Schema, error) {
+	names, err := in.Tables(ctx, schemaName)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &schema.Schema{
+		Name:   schemaName,
+		Tables: make([]*schema.Table, 0, len(names)),
+	}
+	for _, name := range names {
+		table, err := in.Table(ctx, schemaName, name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "inspect table %q", name)
+		}
+		s.Tables = append(s.Tables, table)
+	}
+
+	s.Enums, err = in.Enums(ctx, schemaName)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,202 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"unknwon.dev/norm/adapter"
+	"unknwon.dev/norm/internal/exql"
+	"unknwon.dev/norm/schema"
+)
+
+//go:generate go-mockgen --force unknwon.dev/norm/adapter -i Executor -i Rows -o mock_adapter_test.go
+
+// mockRows returns rows of the given values, where values are assigned to the
+// destinations in the same way as the driver, e.g. pgtype.TextArray is set from
+// []string.
+func mockRows(t *testing.T, values ...[]interface{}) *MockRows {
+	rows := NewMockRows()
+	i := -1
+	rows.NextFunc.SetDefaultHook(func() bool {
+		i++
+		return i < len(values)
+	})
+	rows.ScanFunc.SetDefaultHook(func(dest ...interface{}) error {
+		require.Len(t, dest, len(values[i]))
+		for j, v := range values[i] {
+			switch d := dest[j].(type) {
+			case interface{ Set(src interface{}) error }:
+				require.NoError(t, d.Set(v))
+			case sql.Scanner:
+				require.NoError(t, d.Scan(v))
+			default:
+				reflect.ValueOf(d).Elem().Set(reflect.ValueOf(v))
+			}
+		}
+		return nil
+	})
+	return rows
+}
+
+// query is an expected query of the introspector.
+type query struct {
+	// contains is the substring of the query to identify it.
+	contains string
+	args     []interface{}
+	rows     [][]interface{}
+}
+
+// newTestIntrospector returns an introspector that expects the given queries in
+// order.
+func newTestIntrospector(t *testing.T, queries ...query) *postgresIntrospector {
+	executor := NewMockExecutor()
+	for _, q := range queries {
+		q := q
+		executor.QueryFunc.PushHook(func(_ context.Context, stmt *exql.Statement, args ...interface{}) (adapter.Rows, error) {
+			assert.Equal(t, exql.StatementSQL, stmt.Type)
+			assert.Contains(t, stmt.SQL, q.contains)
+			assert.Equal(t, q.args, args)
+			return mockRows(t, q.rows...), nil
+		})
+	}
+	executor.QueryFunc.SetDefaultHook(func(_ context.Context, stmt *exql.Statement, _ ...interface{}) (adapter.Rows, error) {
+		t.Fatalf("unexpected query: %s", stmt.SQL)
+		return nil, nil
+	})
+	return newPostgresIntrospector(executor)
+}
+
+var (
+	schemasQuery = query{
+		contains: "FROM pg_catalog.pg_namespace",
+		rows:     [][]interface{}{{"app"}, {"public"}},
+	}
+	tablesQuery = query{
+		contains: "FROM pg_catalog.pg_class c",
+		args:     []interface{}{"public"},
+		rows:     [][]interface{}{{"users"}},
+	}
+	columnsQuery = query{
+		contains: "FROM pg_catalog.pg_attribute a",
+		args:     []interface{}{"public", "users"},
+		rows: [][]interface{}{
+			{"id", "bigint", false, "nextval('users_id_seq'::regclass)"},
+			{"org_id", "bigint", true, nil},
+		},
+	}
+	constraintsQuery = query{
+		contains: "FROM pg_catalog.pg_constraint con",
+		args:     []interface{}{"public", "users"},
+		rows: [][]interface{}{
+			{"users_org_id_fkey", "f", []string{"org_id"}, "public", "orgs", []string{"id"}, "a", "c"},
+			{"users_pkey", "p", []string{"id"}, "", "", []string{}, " ", " "},
+		},
+	}
+	indexesQuery = query{
+		contains: "FROM pg_catalog.pg_index i",
+		args:     []interface{}{"public", "users"},
+		rows: [][]interface{}{
+			{"users_pkey", true, true, []string{"id"}, "CREATE UNIQUE INDEX users_pkey ON public.users USING btree (id)"},
+		},
+	}
+	enumsQuery = query{
+		contains: "FROM pg_catalog.pg_type t",
+		args:     []interface{}{"public"},
+		rows:     [][]interface{}{{"mood", []string{"happy", "sad"}}},
+	}
+)
+
+func usersTable() *schema.Table {
+	def := "nextval('users_id_seq'::regclass)"
+	return &schema.Table{
+		Schema: "public",
+		Name:   "users",
+		Columns: []*schema.Column{
+			{Name: "id", Type: "bigint", Default: &def},
+			{Name: "org_id", Type: "bigint", Nullable: true},
+		},
+		PrimaryKey: &schema.PrimaryKey{Name: "users_pkey", Columns: []string{"id"}},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Name:       "users_org_id_fkey",
+				Columns:    []string{"org_id"},
+				RefSchema:  "public",
+				RefTable:   "orgs",
+				RefColumns: []string{"id"},
+				OnUpdate:   "NO ACTION",
+				OnDelete:   "CASCADE",
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:       "users_pkey",
+				Unique:     true,
+				Primary:    true,
+				Columns:    []string{"id"},
+				Definition: "CREATE UNIQUE INDEX users_pkey ON public.users USING btree (id)",
+			},
+		},
+	}
+}
+
+func TestPostgresIntrospector(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Schemas", func(t *testing.T) {
+		got, err := newTestIntrospector(t, schemasQuery).Schemas(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"app", "public"}, got)
+	})
+
+	t.Run("Tables", func(t *testing.T) {
+		got, err := newTestIntrospector(t, tablesQuery).Tables(ctx, "public")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"users"}, got)
+	})
+
+	t.Run("Table", func(t *testing.T) {
+		got, err := newTestIntrospector(t, columnsQuery, constraintsQuery, indexesQuery).Table(ctx, "public", "users")
+		require.NoError(t, err)
+		assert.Equal(t, usersTable(), got)
+	})
+
+	t.Run("Table not exist", func(t *testing.T) {
+		_, err := newTestIntrospector(t, query{contains: columnsQuery.contains, args: []interface{}{"public", "orgs"}}).Table(ctx, "public", "orgs")
+		assert.EqualError(t, err, `table "orgs" does not exist in schema "public"`)
+	})
+
+	t.Run("Enums", func(t *testing.T) {
+		got, err := newTestIntrospector(t, enumsQuery).Enums(ctx, "public")
+		require.NoError(t, err)
+		assert.Equal(t, []*schema.Enum{{Schema: "public", Name: "mood", Values: []string{"happy", "sad"}}}, got)
+	})
+
+	t.Run("Inspect", func(t *testing.T) {
+		got, err := newTestIntrospector(t, tablesQuery, columnsQuery, constraintsQuery, indexesQuery, enumsQuery).Inspect(ctx, "public")
+		require.NoError(t, err)
+
+		want := &schema.Schema{
+			Name:   "public",
+			Tables: []*schema.Table{usersTable()},
+			Enums:  []*schema.Enum{{Schema: "public", Name: "mood", Values: []string{"happy", "sad"}}},
+		}
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		executor := NewMockExecutor()
+		executor.QueryFunc.SetDefaultReturn(nil, errors.New("boom"))
+		_, err := newPostgresIntrospector(executor).Schemas(ctx)
+		assert.EqualError(t, err, "list schemas: query: boom")
+	})
+}
@@ -8,9 +8,12 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"fmt"
+	"reflect"
+	"time"
 
 	"github.com/jackc/pgtype"
 
+	"unknwon.dev/norm/adapter"
 	"unknwon.dev/norm/types"
 )
 
@@ -32,6 +35,51 @@ func (t postgresTyper) Valuer(v interface{}) interface{} {
 	return v
 }
 
+var (
+	int64Type      = reflect.TypeOf(int64(0))
+	float64Type    = reflect.TypeOf(float64(0))
+	boolType       = reflect.TypeOf(false)
+	stringType     = reflect.TypeOf("")
+	bytesType      = reflect.TypeOf([]byte(nil))
+	timeType       = reflect.TypeOf(time.Time{})
+	int64ArrayType = reflect.TypeOf(types.Int64Array(nil))
+)
+
+// scanTypes maps database type names of columns to Go types.
+var scanTypes = map[string]reflect.Type{
+	"INT2":        int64Type,
+	"INT4":        int64Type,
+	"INT8":        int64Type,
+	"OID":         int64Type,
+	"FLOAT4":      float64Type,
+	"FLOAT8":      float64Type,
+	"BOOL":        boolType,
+	"NUMERIC":     stringType,
+	"TEXT":        stringType,
+	"VARCHAR":     stringType,
+	"BPCHAR":      stringType,
+	"NAME":        stringType,
+	"CITEXT":      stringType,
+	"UUID":        stringType,
+	"INET":        stringType,
+	"CIDR":        stringType,
+	"INTERVAL":    stringType,
+	"TIME":        stringType,
+	"BYTEA":       bytesType,
+	"JSON":        bytesType,
+	"JSONB":       bytesType,
+	"DATE":        timeType,
+	"TIMESTAMP":   timeType,
+	"TIMESTAMPTZ": timeType,
+	"_INT2":       int64ArrayType,
+	"_INT4":       int64ArrayType,
+	"_INT8":       int64ArrayType,
+}
+
+func (t postgresTyper) ScanType(ct adapter.ColumnType) reflect.Type {
+	return scanTypes[ct.DatabaseTypeName()]
+}
+
 type int64Array []int64
 
 var _ sql.Scanner = (*int64Array)(nil)
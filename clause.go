@@ -7,6 +7,7 @@ package norm
 
 import (
 	"context"
+	"database/sql"
 )
 
 // Selector represents a SQL query builder for the SELECT statement.
@@ -37,6 +38,23 @@ type Selector interface {
 	// Subsequent calls to Columns() append more columns to the retrieval list (i.e.
 	// do not replace previously set columns).
 	Columns(columns ...interface{}) Selector
+	// ColumnsOf appends columns for every field of the destination type, which is
+	// a struct or a pointer to struct, with aliases that are recognized when
+	// scanning into the same type. Fields are qualified by the given table, and
+	// fields of nested structs are qualified by the name of the nested struct
+	// field, which is expected to be the alias of the joined table:
+	//
+	//   type Book struct {
+	//       ID     int64   `db:"id"`
+	//       Author *Author `db:"author,prefix=author_"`
+	//   }
+	//
+	//   => SELECT "books"."id" AS "id", "author"."id" AS "author_id", ...
+	//   q.ColumnsOf("books", Book{}).From("books").
+	//       LeftJoin("authors AS author").On("author.id = books.author_id")
+	//
+	// A pointer to the nested struct stays nil when all of its columns are NULL.
+	ColumnsOf(table string, dest interface{}) Selector
 	// From constructs the FROM clause for where the data to be retrieved from.
 	//
 	// It is typically used along with Columns():
@@ -161,6 +179,9 @@ type Selector interface {
 	// any type of join method:
 	//
 	//   q.LeftJoin(...).Using("country_id")
+	//
+	// Outer joins of soft-deletable or scoped tables must use On() instead, as
+	// their conditions cannot be added to the join otherwise.
 	Using(columns ...interface{}) Selector
 
 	// Limit constructs the LIMIT clause.
@@ -184,10 +205,33 @@ type Selector interface {
 
 	// Amend alters the query string just before executing it.
 	Amend(func(query string) string) Selector
+	// Strict enables the strict mapping mode for this query, which returns a
+	// *MappingError when any column of query results has no matching field in
+	// the destination struct.
+	Strict() Selector
+	// WithDeleted includes soft-deleted rows of soft-deletable tables in the FROM
+	// and JOIN clauses, which are excluded by default, e.g. conditions of joined
+	// tables are appended to their ON clauses:
+	//
+	//   => SELECT * FROM "users" JOIN "orgs" ON (("orgs"."id" = "users"."org_id") AND "orgs"."deleted_at" IS NULL) WHERE "users"."deleted_at" IS NULL
+	//   q.SelectFrom("users").Join("orgs").On("orgs.id = users.org_id")
+	WithDeleted() Selector
+	// OnlyDeleted only includes soft-deleted rows of soft-deletable tables in the
+	// FROM clause, while soft-deleted rows of joined tables are still excluded:
+	//
+	//   => SELECT * FROM "users" WHERE "users"."deleted_at" IS NOT NULL
+	//   q.SelectFrom("users").OnlyDeleted()
+	OnlyDeleted() Selector
 
 	// Iterate creates an Iterator to iterate over query results.
 	Iterate(ctx context.Context) Iterator
 	ResultMapper
+	// Pluck retrieves values of the single column into the destination, which is
+	// a pointer to the slice of scalars. It replaces any previously set columns:
+	//
+	//   var ids []int64
+	//   err := q.From("users").Where("active = ?", true).Pluck(ctx, "id", &ids)
+	Pluck(ctx context.Context, column interface{}, destSlice interface{}) error
 
 	// String returns a complied SQL query string.
 	String() string
@@ -210,6 +254,16 @@ type Inserter interface {
 	// Example:
 	//
 	//   q.Columns("first_name", "last_name", "age").Values("María", "Méndez", 18)
+	//
+	// A single struct (or pointer to struct) provides values of its fields that
+	// are mapped to columns, using the same mapping as scanning query results.
+	// The columns are derived from the struct unless Columns() is called before,
+	// and lifecycle hooks of the struct are called (see norm.BeforeInserter).
+	// Fields with the "autocreatetime" (unless already set) and "autoupdatetime"
+	// tag options of a pointer to struct are set to DB.Now() before inserting:
+	//
+	//   q.Values(&User{FirstName: "María", LastName: "Méndez", Age: 18})
+	//   q.Columns("first_name", "age").Values(&User{...})
 	Values(values ...interface{}) Inserter
 
 	// Returning constructs the RETURNING clause to specify which columns should be
@@ -219,8 +273,11 @@ type Inserter interface {
 	// Amend alters the query string just before executing it.
 	Amend(func(query string) string) Inserter
 
+	// Exec executes the query without returning any rows.
+	Exec(ctx context.Context) (sql.Result, error)
 	// Iterate creates an Iterator to iterate over query results. This is only
-	// possible when using Returning().
+	// possible when using Returning(). The AfterInsert hooks are called once the
+	// iterator is closed without errors.
 	Iterate(ctx context.Context) Iterator
 	ResultMapper
 
@@ -237,6 +294,29 @@ type Updater interface {
 	// Examples:
 	//
 	//   q.Set("name", "John", "last_name", "Smith").Set("age", 18)
+	//
+	// A single struct (or pointer to struct) sets every column that its fields
+	// are mapped to, using the same mapping as scanning query results. Lifecycle
+	// hooks of the struct are called (see norm.BeforeUpdater). Fields with the
+	// "autoupdatetime" tag option of a pointer to struct are set to DB.Now(), and
+	// fields with the "autocreatetime" tag option are not updated:
+	//
+	//   q.Set(&User{Name: "John", LastName: "Smith", Age: 18})
+	//
+	// An integer field with the "optimisticlock" tag option of the struct is used
+	// for optimistic locking: the column is set to the next version and the WHERE
+	// clause only matches the current version. When no row is updated, Exec, All
+	// and One return an error that wraps norm.ErrStaleObject, otherwise the field
+	// of a pointer to struct is set to the next version. Iterate does not check
+	// the updated rows.
+	//
+	//   type User struct {
+	//       Name    string
+	//       Version int64 `db:"version,optimisticlock"`
+	//   }
+	//
+	//   => UPDATE "users" SET "name" = ?, "version" = ? WHERE (id = ?) AND "version" = ?
+	//   q.Set(&user).Where("id = ?", user.ID)
 	Set(kvs ...interface{}) Updater
 
 	// Where constructs the WHERE clause.
@@ -254,9 +334,18 @@ type Updater interface {
 
 	// Amend alters the query string just before executing it.
 	Amend(func(query string) string) Updater
+	// WithDeleted includes soft-deleted rows when the table is soft-deletable,
+	// which are excluded by default.
+	WithDeleted() Updater
+	// OnlyDeleted only includes soft-deleted rows when the table is
+	// soft-deletable.
+	OnlyDeleted() Updater
 
+	// Exec executes the query without returning any rows.
+	Exec(ctx context.Context) (sql.Result, error)
 	// Iterate creates an Iterator to iterate over query results. This is only
-	// possible when using Returning().
+	// possible when using Returning(). The AfterUpdate hooks are called once the
+	// iterator is closed without errors.
 	Iterate(ctx context.Context) Iterator
 	ResultMapper
 
@@ -283,7 +372,22 @@ type Deleter interface {
 
 	// Amend alters the query string just before executing it.
 	Amend(func(query string) string) Deleter
+	// WithDeleted includes soft-deleted rows when the table is soft-deletable,
+	// which are excluded by default.
+	WithDeleted() Deleter
+	// OnlyDeleted only includes soft-deleted rows when the table is
+	// soft-deletable.
+	OnlyDeleted() Deleter
+	// HardDelete deletes rows from the soft-deletable table instead of marking
+	// them as deleted. Soft-deleted rows are still excluded by default, e.g. to
+	// purge rows that are already marked as deleted:
+	//
+	//   => DELETE FROM "users" WHERE "deleted_at" IS NOT NULL
+	//   q.DeleteFrom("users").HardDelete().OnlyDeleted()
+	HardDelete() Deleter
 
+	// Exec executes the query without returning any rows.
+	Exec(ctx context.Context) (sql.Result, error)
 	// Iterate creates an Iterator to iterate over query results. This is only
 	// possible when using Returning().
 	Iterate(ctx context.Context) Iterator
@@ -297,14 +401,40 @@ type Deleter interface {
 
 // Iterator defines a collection of methods to iterate over query results.
 type Iterator interface {
+	// ResultMapper maps the current result set. The iterator is closed after
+	// mapping a result set unless result sets are iterated by NextResultSet, in
+	// which case it is closed after mapping the last result set. It is also
+	// closed when there is an error.
 	ResultMapper
+
+	// NextResultSet advances the iterator to the next result set, e.g. of stored
+	// procedures or multiple statements. The first call enters the first result
+	// set and keeps the iterator open after mapping each result set. It returns
+	// false and closes the iterator when there is no further result set, Err
+	// should be consulted to distinguish from errors:
+	//
+	//   iter := db.Select(...).Amend(...).Iterate(ctx)
+	//   if !iter.NextResultSet() {
+	//       return iter.Err()
+	//   }
+	//   err := iter.All(ctx, &users)
+	//   ...
+	//   if !iter.NextResultSet() {
+	//       return iter.Err()
+	//   }
+	//   err = iter.All(ctx, &emails)
+	NextResultSet() bool
+	// Err returns the error, if any, that was encountered during iteration.
+	Err() error
+	// Close closes the iterator without mapping the remaining result sets.
+	Close() error
 }
 
 // ResultMapper defines a collection of methods to map query results to
 // destination objects.
 type ResultMapper interface {
 	// All dumps all the results into the destination, and expects a pointer to the
-	// slice of maps or structs.
+	// slice of maps, structs or scalars.
 	//
 	// The behaviour of One() is extended to each one of the results.
 	All(ctx context.Context, destSlice interface{}) error
@@ -315,10 +445,22 @@ type ResultMapper interface {
 	//
 	// If dest is a pointer to a map, each column creates a new map key and the
 	// results are set as values of the keys. Depending on the type of map key and
-	// value, the results columns and values may need to be transformed.
+	// value, the results columns and values may need to be transformed. For maps
+	// of interface{}, values are scanned into Go types by their column types
+	// (e.g. int64, string, time.Time and types.Int64Array) as recognized by the
+	// adapter.Typer, and NULL values are nil.
 	//
 	// If dest is a pointer to a struct, each one field will be tested for a `db`
 	// tag which defines the column name mapping. The results are set as values of
-	// the fields.
+	// the fields. The tag name and the column names of untagged fields can be
+	// configured by MappingOptions.
+	//
+	// If dest is a pointer to a scalar, the result must have exactly one column.
+	// Scalars are types other than maps and structs, as well as sql.Scanner
+	// implementations, time.Time and types that are recognized by the
+	// adapter.Typer, e.g. types.Int64Array:
+	//
+	//   var count int
+	//   err := db.Select(expr.Raw("COUNT(*)")).From("users").One(ctx, &count)
 	One(ctx context.Context, dest interface{}) error
 }
@@ -0,0 +1,102 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Command norm-gen generates Go structs with `db` tags, table name constants
+// and column name constants from the live database schema.
+//
+// Usage:
+//
+//   norm-gen -dsn "postgres://localhost:5432/norm" -package models -output models/tables.go
+package main
+
+import (
+	"context"
+	"flag"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"unknwon.dev/norm/adapter/postgres"
+	"unknwon.dev/norm/internal/codegen"
+	"unknwon.dev/norm/schema"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("norm-gen: ")
+
+	dsn := flag.String("dsn", "", "the data source name of the database")
+	schemaName := flag.String("schema", "public", "the schema to inspect")
+	pkg := flag.String("package", "models", "the package name of the generated code")
+	output := flag.String("output", "", "the file to write the generated code to, default is stdout")
+	tables := flag.String("tables", "", "the comma-separated list of tables to generate, default is all")
+	flag.Parse()
+
+	if *dsn == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	err := run(context.Background(), *dsn, *schemaName, *pkg, *output, *tables)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(ctx context.Context, dsn, schemaName, pkg, output, tables string) error {
+	db, err := postgres.Open(dsn)
+	if err != nil {
+		return errors.Wrap(err, "open database")
+	}
+	defer func() { _ = db.Close() }()
+
+	s, err := db.Adapter().Introspector().Inspect(ctx, schemaName)
+	if err != nil {
+		return errors.Wrap(err, "inspect schema")
+	}
+
+	if tables != "" {
+		s.Tables, err = filterTables(s.Tables, strings.Split(tables, ","))
+		if err != nil {
+			return err
+		}
+	}
+
+	src, err := codegen.Generate(s, codegen.Options{
+		Package: pkg,
+		Command: "norm-gen",
+	})
+	if err != nil {
+		return errors.Wrap(err, "generate")
+	}
+
+	if output == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return ioutil.WriteFile(output, src, 0644)
+}
+
+// filterTables returns the tables with given names in the same order of the
+// names. It returns an error if any of the names does not exist.
+func filterTables(tables []*schema.Table, names []string) ([]*schema.Table, error) {
+	byName := make(map[string]*schema.Table, len(tables))
+	for _, t := range tables {
+		byName[t.Name] = t
+	}
+
+	filtered := make([]*schema.Table, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		t, ok := byName[name]
+		if !ok {
+			return nil, errors.Errorf("table %q does not exist", name)
+		}
+		filtered = append(filtered, t)
+	}
+	return filtered, nil
+}
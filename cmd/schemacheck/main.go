@@ -0,0 +1,66 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Command schemacheck checks the Go structs of tables against a PostgreSQL
+// database and is meant to be run in CI. Because the structs live in the
+// project that owns them, copy this command into the project and list them in
+// the tables variable:
+//
+//   var tables = map[string]interface{}{
+//       "users":  models.User{},
+//       "emails": models.Email{},
+//   }
+//
+// Then run it with:
+//
+//   go run ./cmd/schemacheck -dsn "postgres://localhost:5432/norm" -snake-case -sql
+//
+// The command reports every difference and exits with status code 1 when any
+// table has differences. The -sql flag additionally prints the SQL statements
+// to migrate the database.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"unknwon.dev/norm"
+	"unknwon.dev/norm/schemacheck"
+)
+
+// tables is the map of table names to their Go structs to check.
+var tables = map[string]interface{}{}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("schemacheck: ")
+
+	dsn := flag.String("dsn", "", "the data source name of the database")
+	schemaName := flag.String("schema", "public", "the schema to check against")
+	printSQL := flag.Bool("sql", false, "print the SQL statements to migrate the database")
+	tagName := flag.String("tag", "db", "the name of the struct field tag of columns")
+	snakeCase := flag.Bool("snake-case", false, "use snake_case column names for fields without tags")
+	flag.Parse()
+
+	if *dsn == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	opt := schemacheck.Options{
+		Mapping: norm.MappingOptions{TagName: *tagName},
+	}
+	if *snakeCase {
+		opt.Mapping.NameFunc = norm.SnakeCase
+	}
+
+	err := schemacheck.Run(context.Background(), os.Stdout, *dsn, *schemaName, *printSQL, tables, opt)
+	if err == schemacheck.ErrDrift {
+		os.Exit(1)
+	} else if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -24,6 +24,14 @@ type DB interface {
 	// Close closes the database and prevents new queries from starting. It waits
 	// for all queries that have started processing on the database to finish.
 	Close() error
+	// Conn runs the given function with a database handle that is pinned to a
+	// single connection, e.g. for session-level features like `SET search_path`,
+	// temporary tables, `LISTEN` and session advisory locks. The connection is
+	// returned to the pool after the function returns, thus changes to the
+	// session state should be reverted by the function. The function is run with
+	// the database handle itself when it is already pinned to a connection, e.g.
+	// a transaction.
+	Conn(ctx context.Context, fn func(conn DB) error) error
 
 	Transactor
 	SQL
@@ -71,6 +79,60 @@ type TxOptions struct {
 	Isolation sql.IsolationLevel
 	// ReadOnly indicates whether the transaction will be read-only.
 	ReadOnly bool
+	// Deferrable indicates whether the transaction may block when acquiring its
+	// snapshot, after which it runs without the overhead of serializable
+	// isolation. It only has effect with serializable read-only transactions and
+	// is ignored otherwise.
+	Deferrable bool
+	// Retry is the policy to re-run the transaction after a rollback when it
+	// fails with a retryable error, e.g. serialization failures of the
+	// serializable isolation level. Default is not to retry.
+	Retry *RetryPolicy
+}
+
+// RetryPolicy contains options to retry transactions.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts to run the transaction,
+	// including the first one. The transaction is not retried when it is less
+	// than 2.
+	MaxAttempts int
+	// Backoff returns the duration to wait before the given attempt, which
+	// starts from 2 for the first retry, e.g. ExponentialBackoff. Default is to
+	// retry immediately.
+	Backoff func(attempt int) time.Duration
+	// Retryable returns true if the transaction should be retried for the error.
+	// Default is to retry serialization failures and deadlocks detected by the
+	// database.
+	Retryable func(err error) bool
+}
+
+// ExponentialBackoff returns a backoff policy that doubles the duration to wait
+// before each retry starting from the base, which is capped at the max:
+//
+//   => 10ms, 20ms, 40ms, 80ms, 100ms, 100ms, ...
+//   ExponentialBackoff(10*time.Millisecond, 100*time.Millisecond)
+func ExponentialBackoff(base, max time.Duration) func(attempt int) time.Duration {
+	return func(attempt int) time.Duration {
+		d := base
+		for i := 2; i < attempt && d < max; i++ {
+			d *= 2
+		}
+		if d > max {
+			return max
+		}
+		return d
+	}
+}
+
+// Tx is a transaction started by Transactor.Begin.
+type Tx interface {
+	DB
+	// Commit commits the transaction. It returns sql.ErrTxDone when the
+	// transaction has already been committed or rolled back.
+	Commit() error
+	// Rollback rolls back the transaction. It returns sql.ErrTxDone when the
+	// transaction has already been committed or rolled back.
+	Rollback() error
 }
 
 // Transactor defines a collection of methods to be used with database
@@ -78,6 +140,43 @@ type TxOptions struct {
 type Transactor interface {
 	// Transaction runs the given function in a transaction. It starts and closes
 	// the transaction along with the function execution. The transaction will be
-	// rolled back automatically when the function returns an error.
+	// rolled back automatically when the function returns an error or panics, and
+	// the panic is re-thrown after the rollback.
+	//
+	// Calling Transaction within a transaction starts a nested transaction using a
+	// savepoint, which only rolls back changes made by the function when it
+	// returns an error, e.g. to recover from the failure:
+	//
+	//   db.Transaction(ctx, func(tx norm.DB) error {
+	//       ...
+	//       err := tx.Transaction(ctx, func(tx norm.DB) error {
+	//           ...
+	//       })
+	//       if err != nil {
+	//           // Changes of the nested transaction have been rolled back
+	//       }
+	//       ...
+	//   })
+	//
+	// Use TransactionContext to pass the transaction via the context instead.
 	Transaction(ctx context.Context, fn func(tx DB) error, opts ...*TxOptions) error
+	// Begin starts a transaction that must be ended by calling Commit or Rollback
+	// of the returned Tx, for when the transaction cannot be scoped to a function.
+	// Calling Begin within a transaction starts a nested transaction using a
+	// savepoint. The Retry of options is ignored.
+	Begin(ctx context.Context, opts ...*TxOptions) (Tx, error)
+	// AfterCommit registers the function to be called with the context of the
+	// transaction after it is committed, e.g. to publish events. Functions that
+	// are registered within nested transactions are called along with the
+	// outermost transaction, unless the nested transactions are rolled back. The
+	// function is called immediately with a background context when not in a
+	// transaction.
+	AfterCommit(fn func(ctx context.Context))
+	// AfterRollback registers the function to be called with the context of the
+	// transaction after it is rolled back or fails to commit. Functions that are
+	// registered within nested transactions are called along with the outermost
+	// transaction, which are also called when it is committed if the nested
+	// transactions are rolled back. The function is never called when not in a
+	// transaction.
+	AfterRollback(fn func(ctx context.Context))
 }
@@ -0,0 +1,267 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package norm
+
+import (
+	"context"
+	"database/sql"
+)
+
+// ColumnDefinition defines a column in the CREATE TABLE and ALTER TABLE
+// statements.
+type ColumnDefinition struct {
+	// Name is the name of the column.
+	Name string
+	// Type is the data type of the column, e.g. "BIGINT", "TEXT".
+	Type string
+	// NotNull indicates whether the column has the NOT NULL constraint.
+	NotNull bool
+	// Default is the default value of the column. Use the `expr.Raw` or
+	// `expr.Func` for expressions, e.g. `expr.Func("NOW")`. Any other value is
+	// quoted as a literal.
+	Default interface{}
+	// Constraints is a list of raw column constraints, e.g. "PRIMARY KEY",
+	// "UNIQUE", "REFERENCES users (id)".
+	Constraints []string
+}
+
+// TableCreator represents a SQL query builder for the CREATE TABLE statement.
+type TableCreator interface {
+	// IfNotExists constructs the IF NOT EXISTS clause to not throw an error when
+	// the table already exists.
+	IfNotExists() TableCreator
+	// Columns defines columns of the table.
+	//
+	// Example:
+	//
+	//   q.Columns(
+	//       norm.ColumnDefinition{Name: "id", Type: "BIGSERIAL", Constraints: []string{"PRIMARY KEY"}},
+	//       norm.ColumnDefinition{Name: "email", Type: "TEXT", NotNull: true},
+	//   )
+	//
+	// Subsequent calls to Columns() append more columns to the definition list
+	// (i.e. do not replace previously set columns).
+	Columns(defs ...ColumnDefinition) TableCreator
+	// Constraint defines a table constraint with the given name and raw
+	// definition. The name can be empty to let the database generate one:
+	//
+	//   q.Constraint("users_email_key", "UNIQUE (email)")
+	Constraint(name, definition string) TableCreator
+	// PrimaryKey defines the PRIMARY KEY table constraint with the given name on
+	// the columns, whose names are quoted as identifiers. The name can be empty to
+	// let the database generate one:
+	//
+	//   q.PrimaryKey("", "org_id", "user_id")
+	PrimaryKey(name string, columns ...string) TableCreator
+
+	// Amend alters the query string just before executing it.
+	Amend(func(query string) string) TableCreator
+
+	// Exec executes the query.
+	Exec(ctx context.Context) (sql.Result, error)
+
+	// String returns a complied SQL query string.
+	String() string
+}
+
+// TableAlterer represents a SQL query builder for the ALTER TABLE statement.
+//
+// Multiple actions are applied in the order of the calls:
+//
+//   q.AddColumn(...).DropColumn("nickname")
+type TableAlterer interface {
+	// IfExists constructs the IF EXISTS clause to not throw an error when the
+	// table does not exist.
+	IfExists() TableAlterer
+	// AddColumn adds a column with the given definition.
+	AddColumn(def ColumnDefinition) TableAlterer
+	// DropColumn drops the column with the given name.
+	DropColumn(name string) TableAlterer
+	// RenameColumn renames the column from the name to the new name. It cannot be
+	// combined with other actions.
+	RenameColumn(name, newName string) TableAlterer
+	// AlterColumnType changes the data type of the column with the given name.
+	AlterColumnType(name, typ string) TableAlterer
+	// SetNotNull sets the NOT NULL constraint of the column with the given name.
+	SetNotNull(name string) TableAlterer
+	// DropNotNull drops the NOT NULL constraint of the column with the given
+	// name.
+	DropNotNull(name string) TableAlterer
+	// AddConstraint adds a table constraint with the given name and raw
+	// definition. The name can be empty to let the database generate one:
+	//
+	//   q.AddConstraint("users_email_key", "UNIQUE (email)")
+	AddConstraint(name, definition string) TableAlterer
+	// DropConstraint drops the table constraint with the given name.
+	DropConstraint(name string) TableAlterer
+
+	// Amend alters the query string just before executing it.
+	Amend(func(query string) string) TableAlterer
+
+	// Exec executes the query.
+	Exec(ctx context.Context) (sql.Result, error)
+
+	// String returns a complied SQL query string.
+	String() string
+}
+
+// TableDropper represents a SQL query builder for the DROP TABLE statement.
+type TableDropper interface {
+	// IfExists constructs the IF EXISTS clause to not throw an error when the
+	// table does not exist.
+	IfExists() TableDropper
+	// Cascade constructs the CASCADE clause to automatically drop objects that
+	// depend on the table.
+	Cascade() TableDropper
+
+	// Amend alters the query string just before executing it.
+	Amend(func(query string) string) TableDropper
+
+	// Exec executes the query.
+	Exec(ctx context.Context) (sql.Result, error)
+
+	// String returns a complied SQL query string.
+	String() string
+}
+
+// DatabaseDropper represents a SQL query builder for the DROP DATABASE
+// statement.
+type DatabaseDropper interface {
+	// IfExists constructs the IF EXISTS clause to not throw an error when the
+	// database does not exist.
+	IfExists() DatabaseDropper
+
+	// Amend alters the query string just before executing it.
+	Amend(func(query string) string) DatabaseDropper
+
+	// Exec executes the query.
+	Exec(ctx context.Context) (sql.Result, error)
+
+	// String returns a complied SQL query string.
+	String() string
+}
+
+// Truncater represents a SQL query builder for the TRUNCATE statement.
+type Truncater interface {
+	// RestartIdentity constructs the RESTART IDENTITY clause to restart sequences
+	// owned by columns of the truncated tables.
+	RestartIdentity() Truncater
+	// Cascade constructs the CASCADE clause to automatically truncate tables that
+	// have foreign-key references to the truncated tables.
+	Cascade() Truncater
+
+	// Amend alters the query string just before executing it.
+	Amend(func(query string) string) Truncater
+
+	// Exec executes the query.
+	Exec(ctx context.Context) (sql.Result, error)
+
+	// String returns a complied SQL query string.
+	String() string
+}
+
+// IndexCreator represents a SQL query builder for the CREATE INDEX statement.
+type IndexCreator interface {
+	// On specifies the table that the index is created on.
+	On(table string) IndexCreator
+	// Columns defines the indexed columns. Use the `expr.Raw` for expressions:
+	//
+	//   q.Columns("email", expr.Raw("lower(name)"))
+	//
+	// Subsequent calls to Columns() append more columns to the index (i.e. do not
+	// replace previously set columns).
+	Columns(columns ...interface{}) IndexCreator
+	// Unique constructs the UNIQUE clause to create a unique index.
+	Unique() IndexCreator
+	// IfNotExists constructs the IF NOT EXISTS clause to not throw an error when
+	// the index already exists.
+	IfNotExists() IndexCreator
+	// Where constructs the WHERE clause to create a partial index. The conditions
+	// are accepted in the same forms as the Selector's Where(), except that bind
+	// parameters are not supported:
+	//
+	//   q.Where("deleted_at IS NULL")
+	Where(conds ...interface{}) IndexCreator
+	// Concurrently constructs the CONCURRENTLY clause to build the index without
+	// locking out writes on the table. A query with this clause cannot be executed
+	// within a transaction.
+	Concurrently() IndexCreator
+
+	// Amend alters the query string just before executing it.
+	Amend(func(query string) string) IndexCreator
+
+	// Exec executes the query.
+	Exec(ctx context.Context) (sql.Result, error)
+
+	// String returns a complied SQL query string.
+	String() string
+}
+
+// IndexDropper represents a SQL query builder for the DROP INDEX statement.
+type IndexDropper interface {
+	// IfExists constructs the IF EXISTS clause to not throw an error when the
+	// index does not exist.
+	IfExists() IndexDropper
+	// Cascade constructs the CASCADE clause to automatically drop objects that
+	// depend on the index.
+	Cascade() IndexDropper
+	// Concurrently constructs the CONCURRENTLY clause to drop the index without
+	// locking out concurrent queries on the table. A query with this clause cannot
+	// be executed within a transaction.
+	Concurrently() IndexDropper
+
+	// Amend alters the query string just before executing it.
+	Amend(func(query string) string) IndexDropper
+
+	// Exec executes the query.
+	Exec(ctx context.Context) (sql.Result, error)
+
+	// String returns a complied SQL query string.
+	String() string
+}
+
+// ViewCreator represents a SQL query builder for the CREATE VIEW and CREATE
+// MATERIALIZED VIEW statements.
+type ViewCreator interface {
+	// OrReplace constructs the OR REPLACE clause to replace the view when it
+	// already exists. It is not applicable to materialized views.
+	OrReplace() ViewCreator
+	// IfNotExists constructs the IF NOT EXISTS clause to not throw an error when
+	// the materialized view already exists. It is only applicable to materialized
+	// views.
+	IfNotExists() ViewCreator
+	// As specifies the query of the view, which can be a string, an `expr.Raw` or a
+	// Selector. Bind parameters are not supported:
+	//
+	//   q.As(db.SelectFrom("users").Where("deleted_at IS NULL"))
+	As(query interface{}) ViewCreator
+
+	// Amend alters the query string just before executing it.
+	Amend(func(query string) string) ViewCreator
+
+	// Exec executes the query.
+	Exec(ctx context.Context) (sql.Result, error)
+
+	// String returns a complied SQL query string.
+	String() string
+}
+
+// MaterializedViewRefresher represents a SQL query builder for the REFRESH
+// MATERIALIZED VIEW statement.
+type MaterializedViewRefresher interface {
+	// Concurrently constructs the CONCURRENTLY clause to refresh the materialized
+	// view without locking out concurrent selects on it. A query with this clause
+	// cannot be executed within a transaction.
+	Concurrently() MaterializedViewRefresher
+
+	// Amend alters the query string just before executing it.
+	Amend(func(query string) string) MaterializedViewRefresher
+
+	// Exec executes the query.
+	Exec(ctx context.Context) (sql.Result, error)
+
+	// String returns a complied SQL query string.
+	String() string
+}
@@ -4,6 +4,7 @@ go 1.16
 
 require (
 	github.com/derision-test/go-mockgen v1.1.3
+	github.com/jackc/pgconn v1.10.1
 	github.com/jackc/pgtype v1.9.1
 	github.com/jackc/pgx/v4 v4.14.1
 	github.com/mitchellh/hashstructure/v2 v2.0.2
@@ -0,0 +1,72 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package norm
+
+import (
+	"context"
+)
+
+// Model lifecycle hooks are optional interfaces that are implemented by
+// structs to be invoked by query builders. Hooks of inserts and updates are
+// called on the structs that are passed to Inserter.Values and Updater.Set,
+// and AfterFind is called on every scanned value of query results. The db is
+// the database handle (or transaction) that runs the query.
+//
+// An error returned by a hook aborts the operation, thus rolls back the
+// transaction when it is returned from the function of Transaction:
+//
+//   func (u *User) BeforeInsert(ctx context.Context, db norm.DB) error {
+//       u.Name = strings.TrimSpace(u.Name)
+//       return nil
+//   }
+//
+//   func (u *User) Validate() error {
+//       if u.Name == "" {
+//           return errors.New("empty name")
+//       }
+//       return nil
+//   }
+
+// BeforeInserter is implemented by structs to be called before they are
+// inserted.
+type BeforeInserter interface {
+	BeforeInsert(ctx context.Context, db DB) error
+}
+
+// AfterInserter is implemented by structs to be called after they are
+// inserted. For queries with the RETURNING clause, it is called after the
+// results are mapped by Inserter.All and Inserter.One, or after the iterator of
+// Inserter.Iterate is closed without errors, i.e. when all results are mapped or
+// Close is called.
+type AfterInserter interface {
+	AfterInsert(ctx context.Context, db DB) error
+}
+
+// BeforeUpdater is implemented by structs to be called before they are used to
+// update rows.
+type BeforeUpdater interface {
+	BeforeUpdate(ctx context.Context, db DB) error
+}
+
+// AfterUpdater is implemented by structs to be called after they are used to
+// update rows. For queries with the RETURNING clause, it is called after the
+// results are mapped by Updater.All and Updater.One, or after the iterator of
+// Updater.Iterate is closed without errors, i.e. when all results are mapped or
+// Close is called.
+type AfterUpdater interface {
+	AfterUpdate(ctx context.Context, db DB) error
+}
+
+// AfterFinder is implemented by structs to be called after they are scanned
+// from query results.
+type AfterFinder interface {
+	AfterFind(ctx context.Context, db DB) error
+}
+
+// Validator is implemented by structs to be validated before they are inserted
+// or used to update rows, after the BeforeInsert and BeforeUpdate hooks.
+type Validator interface {
+	Validate() error
+}
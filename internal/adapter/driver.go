@@ -60,3 +60,29 @@ func (d *BaseTxDriver) SetMaxIdleConns(int) {
 func (d *BaseTxDriver) SetMaxOpenConns(int) {
 	panic("SetMaxOpenConns is not available within a transaction")
 }
+
+type BaseConnDriver struct {
+	*sql.Conn
+}
+
+func NewBaseConnDriver(conn *sql.Conn) *BaseConnDriver {
+	return &BaseConnDriver{
+		Conn: conn,
+	}
+}
+
+func (d *BaseConnDriver) SetConnMaxLifetime(time.Duration) {
+	panic("SetConnMaxLifetime is not available on a single connection")
+}
+
+func (d *BaseConnDriver) SetConnMaxIdleTime(time.Duration) {
+	panic("SetConnMaxIdleTime is not available on a single connection")
+}
+
+func (d *BaseConnDriver) SetMaxIdleConns(int) {
+	panic("SetMaxIdleConns is not available on a single connection")
+}
+
+func (d *BaseConnDriver) SetMaxOpenConns(int) {
+	panic("SetMaxOpenConns is not available on a single connection")
+}
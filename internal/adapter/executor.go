@@ -7,6 +7,7 @@ package adapter
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -15,18 +16,23 @@ import (
 	"unknwon.dev/norm/internal/sqlbuilder"
 )
 
-type BaseDBExecutor struct {
-	db      *sql.DB // todo: Factor out 4 methods so we can have an interface here
-	t       *exql.Template
-	adapter adapter.Adapter
+// queryer is implemented by *sql.DB, *sql.Tx and *sql.Conn to execute
+// queries.
+type queryer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
 }
 
-func NewBaseDBExecutor(db *sql.DB, t *exql.Template, adapter adapter.Adapter) *BaseDBExecutor {
-	return &BaseDBExecutor{
-		db:      db,
-		t:       t,
-		adapter: adapter,
-	}
+// baseExecutor is the executor that executes queries using the queryer through
+// the chain of interceptors.
+type baseExecutor struct {
+	queryer       queryer
+	t             *exql.Template
+	adapter       adapter.Adapter
+	interceptors  []adapter.Interceptor
+	inTransaction bool
 }
 
 func compileStatement(t *exql.Template, adapter adapter.Adapter, stmt *exql.Statement, args []interface{}) (string, []interface{}, error) {
@@ -35,6 +41,12 @@ func compileStatement(t *exql.Template, adapter adapter.Adapter, stmt *exql.Stat
 		return "", nil, err
 	}
 
+	// Raw SQL without arguments is sent as-is, formatting would otherwise mangle
+	// comments and literal question marks (e.g. JSONB operators) in scripts.
+	if stmt.Type == exql.StatementSQL && len(args) == 0 {
+		return q, nil, nil
+	}
+
 	for i := range args {
 		args[i] = adapter.Typer().Valuer(args[i])
 	}
@@ -48,80 +60,141 @@ func compileStatement(t *exql.Template, adapter adapter.Adapter, stmt *exql.Stat
 	return q, args, nil
 }
 
-func (e *BaseDBExecutor) Exec(ctx context.Context, stmt *exql.Statement, args ...interface{}) (sql.Result, error) {
+// execute compiles the statement and calls the handler with the query through
+// the chain of interceptors.
+func (e *baseExecutor) execute(ctx context.Context, method adapter.QueryMethod, stmt *exql.Statement, args []interface{}, handler adapter.QueryHandler) (interface{}, error) {
 	s, args, err := compileStatement(e.t, e.adapter, stmt, args)
 	if err != nil {
 		return nil, err
 	}
-	return e.db.ExecContext(ctx, s, args...)
-}
 
-func (e *BaseDBExecutor) Prepare(ctx context.Context, stmt *exql.Statement) (*sql.Stmt, error) {
-	s, _, err := compileStatement(e.t, e.adapter, stmt, nil)
-	if err != nil {
-		return nil, err
+	info := adapter.QueryInfo{
+		Method:        method,
+		SQL:           s,
+		Args:          args,
+		InTransaction: e.inTransaction,
+	}
+	if len(e.interceptors) == 0 {
+		return handler(ctx, info)
 	}
-	return e.db.PrepareContext(ctx, s)
-}
 
-func (e *BaseDBExecutor) Query(ctx context.Context, stmt *exql.Statement, args ...interface{}) (adapter.Rows, error) {
-	s, args, err := compileStatement(e.t, e.adapter, stmt, args)
-	if err != nil {
-		return nil, err
+	info.Type = stmt.Type.String()
+	info.Table = stmt.TableName()
+	info.StartedAt = time.Now()
+	next := handler
+	for i := len(e.interceptors) - 1; i >= 0; i-- {
+		interceptor, inner := e.interceptors[i], next
+		next = func(ctx context.Context, info adapter.QueryInfo) (interface{}, error) {
+			return interceptor(ctx, info, inner)
+		}
 	}
-	return e.db.QueryContext(ctx, s, args...) //nolint:rowserrcheck
+	return next(ctx, info)
 }
 
-func (e *BaseDBExecutor) QueryRow(ctx context.Context, stmt *exql.Statement, args ...interface{}) (*sql.Row, error) {
-	s, args, err := compileStatement(e.t, e.adapter, stmt, args)
-	if err != nil {
-		return nil, err
+// unexpectedResult returns the error of a result that does not correspond to
+// the method, which is returned by interceptors without an error.
+func unexpectedResult(method adapter.QueryMethod, v interface{}) error {
+	return errors.Errorf("unexpected result %T of %s without an error", v, method)
+}
+
+func (e *baseExecutor) Exec(ctx context.Context, stmt *exql.Statement, args ...interface{}) (sql.Result, error) {
+	v, err := e.execute(ctx, adapter.MethodExec, stmt, args,
+		func(ctx context.Context, info adapter.QueryInfo) (interface{}, error) {
+			return e.queryer.ExecContext(ctx, info.SQL, info.Args...)
+		},
+	)
+	result, ok := v.(sql.Result)
+	if err == nil && (!ok || result == nil) {
+		return nil, unexpectedResult(adapter.MethodExec, v)
 	}
-	return e.db.QueryRowContext(ctx, s, args...), nil
+	return result, err
 }
 
-type BaseTxExecutor struct {
-	tx      *sql.Tx
-	t       *exql.Template
-	adapter adapter.Adapter
+func (e *baseExecutor) Prepare(ctx context.Context, stmt *exql.Statement) (*sql.Stmt, error) {
+	v, err := e.execute(ctx, adapter.MethodPrepare, stmt, nil,
+		func(ctx context.Context, info adapter.QueryInfo) (interface{}, error) {
+			return e.queryer.PrepareContext(ctx, info.SQL)
+		},
+	)
+	s, ok := v.(*sql.Stmt)
+	if err == nil && (!ok || s == nil) {
+		return nil, unexpectedResult(adapter.MethodPrepare, v)
+	}
+	return s, err
 }
 
-func NewBaseTxExecutor(tx *sql.Tx, t *exql.Template, adapter adapter.Adapter) *BaseTxExecutor {
-	return &BaseTxExecutor{
-		tx:      tx,
-		t:       t,
-		adapter: adapter,
+func (e *baseExecutor) Query(ctx context.Context, stmt *exql.Statement, args ...interface{}) (adapter.Rows, error) {
+	v, err := e.execute(ctx, adapter.MethodQuery, stmt, args,
+		func(ctx context.Context, info adapter.QueryInfo) (interface{}, error) {
+			return newRows(e.queryer.QueryContext(ctx, info.SQL, info.Args...)) //nolint:rowserrcheck
+		},
+	)
+	rows, ok := v.(adapter.Rows)
+	if err == nil && (!ok || rows == nil) {
+		return nil, unexpectedResult(adapter.MethodQuery, v)
 	}
+	return rows, err
 }
 
-func (e *BaseTxExecutor) Exec(ctx context.Context, stmt *exql.Statement, args ...interface{}) (sql.Result, error) {
-	s, args, err := compileStatement(e.t, e.adapter, stmt, args)
-	if err != nil {
-		return nil, err
+func (e *baseExecutor) QueryRow(ctx context.Context, stmt *exql.Statement, args ...interface{}) (*sql.Row, error) {
+	v, err := e.execute(ctx, adapter.MethodQueryRow, stmt, args,
+		func(ctx context.Context, info adapter.QueryInfo) (interface{}, error) {
+			return e.queryer.QueryRowContext(ctx, info.SQL, info.Args...), nil
+		},
+	)
+	row, ok := v.(*sql.Row)
+	if err == nil && (!ok || row == nil) {
+		return nil, unexpectedResult(adapter.MethodQueryRow, v)
 	}
-	return e.tx.ExecContext(ctx, s, args...)
+	return row, err
 }
 
-func (e *BaseTxExecutor) Prepare(ctx context.Context, stmt *exql.Statement) (*sql.Stmt, error) {
-	s, _, err := compileStatement(e.t, e.adapter, stmt, nil)
-	if err != nil {
-		return nil, err
+func (e *baseExecutor) InTransaction() bool {
+	return e.inTransaction
+}
+
+type BaseDBExecutor struct {
+	*baseExecutor
+}
+
+func NewBaseDBExecutor(db *sql.DB, t *exql.Template, adapter adapter.Adapter, interceptors []adapter.Interceptor) *BaseDBExecutor {
+	return &BaseDBExecutor{
+		baseExecutor: &baseExecutor{
+			queryer:      db,
+			t:            t,
+			adapter:      adapter,
+			interceptors: interceptors,
+		},
 	}
-	return e.tx.PrepareContext(ctx, s)
 }
 
-func (e *BaseTxExecutor) Query(ctx context.Context, stmt *exql.Statement, args ...interface{}) (adapter.Rows, error) {
-	s, args, err := compileStatement(e.t, e.adapter, stmt, args)
-	if err != nil {
-		return nil, err
+type BaseTxExecutor struct {
+	*baseExecutor
+}
+
+func NewBaseTxExecutor(tx *sql.Tx, t *exql.Template, adapter adapter.Adapter, interceptors []adapter.Interceptor) *BaseTxExecutor {
+	return &BaseTxExecutor{
+		baseExecutor: &baseExecutor{
+			queryer:       tx,
+			t:             t,
+			adapter:       adapter,
+			interceptors:  interceptors,
+			inTransaction: true,
+		},
 	}
-	return e.tx.QueryContext(ctx, s, args...) //nolint:rowserrcheck
 }
 
-func (e *BaseTxExecutor) QueryRow(ctx context.Context, stmt *exql.Statement, args ...interface{}) (*sql.Row, error) {
-	s, args, err := compileStatement(e.t, e.adapter, stmt, args)
-	if err != nil {
-		return nil, err
+type BaseConnExecutor struct {
+	*baseExecutor
+}
+
+func NewBaseConnExecutor(conn *sql.Conn, t *exql.Template, adapter adapter.Adapter, interceptors []adapter.Interceptor) *BaseConnExecutor {
+	return &BaseConnExecutor{
+		baseExecutor: &baseExecutor{
+			queryer:      conn,
+			t:            t,
+			adapter:      adapter,
+			interceptors: interceptors,
+		},
 	}
-	return e.tx.QueryRowContext(ctx, s, args...), nil
 }
@@ -0,0 +1,182 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package adapter
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"unknwon.dev/norm/adapter"
+	"unknwon.dev/norm/internal/exql"
+)
+
+// fakeQueryer records the queries that are executed.
+type fakeQueryer struct {
+	queries []string
+	args    [][]interface{}
+}
+
+var _ queryer = (*fakeQueryer)(nil)
+
+func (q *fakeQueryer) ExecContext(_ context.Context, query string, args ...interface{}) (sql.Result, error) {
+	q.queries = append(q.queries, query)
+	q.args = append(q.args, args)
+	return driver.RowsAffected(1), nil
+}
+
+func (q *fakeQueryer) PrepareContext(context.Context, string) (*sql.Stmt, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (q *fakeQueryer) QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (q *fakeQueryer) QueryRowContext(context.Context, string, ...interface{}) *sql.Row {
+	return nil
+}
+
+//go:generate go-mockgen --force unknwon.dev/norm/adapter -i Adapter -i Typer -o mock_adapter_test.go
+func newTestExecutor(t *testing.T, q *fakeQueryer, interceptors ...adapter.Interceptor) *baseExecutor {
+	tmpl, err := exql.DefaultTemplate()
+	require.NoError(t, err)
+
+	typer := NewMockTyper()
+	typer.ValuerFunc.SetDefaultHook(func(v interface{}) interface{} {
+		return v
+	})
+	adp := NewMockAdapter()
+	adp.TyperFunc.SetDefaultReturn(typer)
+	adp.FormatSQLFunc.SetDefaultHook(exql.StripWhitespace)
+
+	return &baseExecutor{
+		queryer:       q,
+		t:             tmpl,
+		adapter:       adp,
+		interceptors:  interceptors,
+		inTransaction: true,
+	}
+}
+
+// deleteStatement returns the statement to delete the user with given ID.
+func deleteStatement() *exql.Statement {
+	return &exql.Statement{
+		Type:  exql.StatementDelete,
+		Table: exql.Table("users"),
+		Where: exql.Where(exql.Raw("id = ?")),
+	}
+}
+
+func TestBaseExecutor_Interceptors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no interceptors", func(t *testing.T) {
+		q := &fakeQueryer{}
+		_, err := newTestExecutor(t, q).Exec(ctx, deleteStatement(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, []string{`DELETE FROM "users" WHERE id = ?`}, q.queries)
+		assert.Equal(t, [][]interface{}{{1}}, q.args)
+	})
+
+	t.Run("chain", func(t *testing.T) {
+		var calls []string
+		var infos []adapter.QueryInfo
+		intercept := func(name string) adapter.Interceptor {
+			return func(ctx context.Context, info adapter.QueryInfo, next adapter.QueryHandler) (interface{}, error) {
+				calls = append(calls, name+" before")
+				infos = append(infos, info)
+				info.Args = append(info.Args, name)
+				result, err := next(ctx, info)
+				calls = append(calls, name+" after")
+				return result, err
+			}
+		}
+
+		q := &fakeQueryer{}
+		result, err := newTestExecutor(t, q, intercept("outer"), intercept("inner")).Exec(ctx, deleteStatement(), 1)
+		require.NoError(t, err)
+		affected, err := result.RowsAffected()
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), affected)
+
+		assert.Equal(t, []string{"outer before", "inner before", "inner after", "outer after"}, calls)
+		assert.Equal(t, [][]interface{}{{1, "outer", "inner"}}, q.args)
+
+		require.Len(t, infos, 2)
+		got := infos[0]
+		assert.False(t, got.StartedAt.IsZero())
+		got.StartedAt = infos[1].StartedAt
+		want := adapter.QueryInfo{
+			Method:        adapter.MethodExec,
+			Type:          "DELETE",
+			Table:         "users",
+			SQL:           `DELETE FROM "users" WHERE id = ?`,
+			Args:          []interface{}{1},
+			InTransaction: true,
+			StartedAt:     infos[1].StartedAt,
+		}
+		assert.Equal(t, want, got)
+		assert.Equal(t, []interface{}{1, "outer"}, infos[1].Args)
+	})
+
+	t.Run("short-circuit", func(t *testing.T) {
+		q := &fakeQueryer{}
+		result, err := newTestExecutor(t, q,
+			func(context.Context, adapter.QueryInfo, adapter.QueryHandler) (interface{}, error) {
+				return driver.RowsAffected(2), nil
+			},
+		).Exec(ctx, deleteStatement(), 1)
+		require.NoError(t, err)
+		assert.Empty(t, q.queries)
+
+		affected, err := result.RowsAffected()
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), affected)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		var got error
+		q := &fakeQueryer{}
+		_, err := newTestExecutor(t, q,
+			func(ctx context.Context, info adapter.QueryInfo, next adapter.QueryHandler) (interface{}, error) {
+				result, err := next(ctx, info)
+				got = err
+				return result, errors.Wrap(err, "outer")
+			},
+			func(context.Context, adapter.QueryInfo, adapter.QueryHandler) (interface{}, error) {
+				return nil, errors.New("boom")
+			},
+		).Exec(ctx, deleteStatement(), 1)
+		assert.EqualError(t, err, "outer: boom")
+		assert.EqualError(t, got, "boom")
+		assert.Empty(t, q.queries)
+	})
+
+	t.Run("unexpected result", func(t *testing.T) {
+		e := newTestExecutor(t, &fakeQueryer{},
+			func(_ context.Context, info adapter.QueryInfo, _ adapter.QueryHandler) (interface{}, error) {
+				if info.Method == adapter.MethodQuery {
+					return driver.RowsAffected(1), nil
+				}
+				return nil, nil
+			},
+		)
+
+		_, err := e.Exec(ctx, deleteStatement(), 1)
+		assert.EqualError(t, err, "unexpected result <nil> of Exec without an error")
+		_, err = e.Prepare(ctx, deleteStatement())
+		assert.EqualError(t, err, "unexpected result <nil> of Prepare without an error")
+		_, err = e.Query(ctx, deleteStatement(), 1)
+		assert.EqualError(t, err, "unexpected result driver.RowsAffected of Query without an error")
+		_, err = e.QueryRow(ctx, deleteStatement(), 1)
+		assert.EqualError(t, err, "unexpected result <nil> of QueryRow without an error")
+	})
+}
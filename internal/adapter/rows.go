@@ -0,0 +1,39 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package adapter
+
+import (
+	"database/sql"
+
+	"unknwon.dev/norm/adapter"
+)
+
+var _ adapter.Rows = (*sqlRows)(nil)
+
+// sqlRows wraps the *sql.Rows to be an adapter.Rows.
+type sqlRows struct {
+	*sql.Rows
+}
+
+// newRows returns the adapter.Rows that wraps the *sql.Rows, or the error as-is.
+func newRows(rows *sql.Rows, err error) (adapter.Rows, error) {
+	if err != nil {
+		return nil, err
+	}
+	return &sqlRows{Rows: rows}, nil
+}
+
+func (r *sqlRows) ColumnTypes() ([]adapter.ColumnType, error) {
+	types, err := r.Rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	cts := make([]adapter.ColumnType, len(types))
+	for i := range types {
+		cts[i] = types[i]
+	}
+	return cts, nil
+}
@@ -51,17 +51,30 @@ type item struct {
 // Get attempts to retrieve a cached value as a string. It returns false if the
 // value does not exist or cannot be type-casted to a string.
 func (c *LRU) Get(h Hashable) (string, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+	v, ok := c.Load(h)
+	if !ok {
+		return "", false
+	}
+
+	s, ok := v.(string)
+	return s, ok
+}
+
+// Load attempts to retrieve a cached value. It returns false if the value does
+// not exist.
+func (c *LRU) Load(h Hashable) (interface{}, bool) {
+	// Moving the element to the front mutates the list, thus a write lock is
+	// required.
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
 	e, ok := c.data[h.Hash()]
 	if !ok {
-		return "", false
+		return nil, false
 	}
 
 	c.list.MoveToFront(e)
-	s, ok := e.Value.(*item).value.(string)
-	return s, ok
+	return e.Value.(*item).value, true
 }
 
 // Set stores the given value to the cache.
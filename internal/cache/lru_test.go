@@ -33,6 +33,23 @@ func TestLRU(t *testing.T) {
 	assert.Equal(t, "bar", got)
 }
 
+func TestLRU_Load(t *testing.T) {
+	c := NewLRU()
+
+	h := &hashable{Name: "foo"}
+	_, ok := c.Load(h)
+	assert.False(t, ok, "cache miss")
+
+	v := &hashable{Name: "bar"}
+	c.Set(h, v)
+	got, ok := c.Load(h)
+	assert.True(t, ok, "cache hit")
+	assert.Same(t, v, got)
+
+	_, ok = c.Get(h)
+	assert.False(t, ok, "not a string")
+}
+
 func TestLRU_Capacity(t *testing.T) {
 	c := NewLRU(
 		Options{
@@ -0,0 +1,271 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package codegen generates Go code from database schemas.
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"unknwon.dev/norm/schema"
+)
+
+// Options contains options for generating Go code.
+type Options struct {
+	// Package is the name of the Go package of the generated code.
+	Package string
+	// Command is the command to be recorded in the header of the generated code.
+	Command string
+}
+
+// goType is a Go type with the package it needs to import.
+type goType struct {
+	name       string
+	importPath string
+	// nilable indicates whether the type can represent NULL values without being a
+	// pointer.
+	nilable bool
+}
+
+// baseTypes maps PostgreSQL data types (without modifiers) to Go types.
+var baseTypes = map[string]goType{
+	"smallint":                    {name: "int16"},
+	"integer":                     {name: "int32"},
+	"bigint":                      {name: "int64"},
+	"real":                        {name: "float32"},
+	"double precision":            {name: "float64"},
+	"numeric":                     {name: "string"},
+	"boolean":                     {name: "bool"},
+	"text":                        {name: "string"},
+	"character varying":           {name: "string"},
+	"character":                   {name: "string"},
+	"citext":                      {name: "string"},
+	"uuid":                        {name: "string"},
+	"inet":                        {name: "string"},
+	"cidr":                        {name: "string"},
+	"interval":                    {name: "string"},
+	"time without time zone":      {name: "string"},
+	"time with time zone":         {name: "string"},
+	"bytea":                       {name: "[]byte", nilable: true},
+	"json":                        {name: "[]byte", nilable: true},
+	"jsonb":                       {name: "[]byte", nilable: true},
+	"date":                        {name: "time.Time", importPath: "time"},
+	"timestamp without time zone": {name: "time.Time", importPath: "time"},
+	"timestamp with time zone":    {name: "time.Time", importPath: "time"},
+	"smallint[]":                  {name: "types.Int64Array", importPath: "unknwon.dev/norm/types", nilable: true},
+	"integer[]":                   {name: "types.Int64Array", importPath: "unknwon.dev/norm/types", nilable: true},
+	"bigint[]":                    {name: "types.Int64Array", importPath: "unknwon.dev/norm/types", nilable: true},
+}
+
+// fallbackType is the Go type for unrecognized data types.
+var fallbackType = goType{name: "interface{}", nilable: true}
+
+// stripModifiers removes type modifiers from the data type, e.g.
+// "character varying(255)" becomes "character varying" and
+// "timestamp(3) with time zone" becomes "timestamp with time zone".
+func stripModifiers(typ string) string {
+	for {
+		start := strings.IndexByte(typ, '(')
+		if start < 0 {
+			return typ
+		}
+		end := strings.IndexByte(typ[start:], ')')
+		if end < 0 {
+			return typ
+		}
+		typ = typ[:start] + typ[start+end+1:]
+	}
+}
+
+// mapType returns the Go type of the column.
+func mapType(c *schema.Column, enums map[string]bool) goType {
+	typ := stripModifiers(c.Type)
+
+	t, ok := baseTypes[typ]
+	if !ok {
+		if enums[typ] {
+			t = goType{name: "string"}
+		} else {
+			t = fallbackType
+		}
+	}
+
+	if c.Nullable && !t.nilable {
+		t.name = "*" + t.name
+	}
+	return t
+}
+
+// commonInitialisms is the set of words that are written in all capitals in Go
+// identifiers.
+var commonInitialisms = map[string]bool{
+	"acl": true, "api": true, "ascii": true, "cpu": true, "css": true,
+	"dns": true, "eof": true, "guid": true, "html": true, "http": true,
+	"https": true, "id": true, "ip": true, "json": true, "lhs": true,
+	"qps": true, "ram": true, "rhs": true, "rpc": true, "sla": true,
+	"smtp": true, "sql": true, "ssh": true, "tcp": true, "tls": true,
+	"ttl": true, "udp": true, "ui": true, "uid": true, "uri": true,
+	"url": true, "utf8": true, "uuid": true, "vm": true, "xml": true,
+}
+
+// identifier converts the snake_case name to an exported Go identifier, e.g.
+// "user_id" becomes "UserID" and "team_ids" becomes "TeamIDs".
+func identifier(name string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	}) {
+		lower := strings.ToLower(word)
+		if commonInitialisms[lower] {
+			b.WriteString(strings.ToUpper(lower))
+			continue
+		} else if strings.HasSuffix(lower, "s") && commonInitialisms[strings.TrimSuffix(lower, "s")] {
+			// Plural forms, e.g. "ids" becomes "IDs"
+			b.WriteString(strings.ToUpper(strings.TrimSuffix(lower, "s")) + "s")
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]))
+		b.WriteString(word[1:])
+	}
+
+	ident := b.String()
+	if ident == "" || ident[0] >= '0' && ident[0] <= '9' {
+		ident = "X" + ident
+	}
+	return ident
+}
+
+// Generate returns the formatted Go code that contains a struct, a table name
+// constant and column name constants for each table in the schema. The output
+// is deterministic for the same input.
+func Generate(s *schema.Schema, opts Options) ([]byte, error) {
+	if opts.Package == "" {
+		return nil, errors.New("empty package name")
+	}
+
+	enums := make(map[string]bool, len(s.Enums))
+	for _, e := range s.Enums {
+		enums[e.Name] = true
+		enums[e.Schema+"."+e.Name] = true
+	}
+
+	tables := make([]*schema.Table, len(s.Tables))
+	copy(tables, s.Tables)
+	sort.Slice(tables, func(i, j int) bool {
+		return tables[i].Name < tables[j].Name
+	})
+
+	// declared maps identifiers of the package to what they are declared for,
+	// e.g. "UsersTable" of `the name of table "users"`.
+	declared := make(map[string]string)
+	declare := func(ident, what string) error {
+		if prev, ok := declared[ident]; ok {
+			return errors.Errorf("identifier %q of %s collides with the one of %s", ident, what, prev)
+		}
+		declared[ident] = what
+		return nil
+	}
+
+	imports := make(map[string]bool)
+	var body bytes.Buffer
+	for _, table := range tables {
+		ident := identifier(table.Name)
+		tableName := table.Name
+		if table.Schema != "" && table.Schema != "public" {
+			tableName = table.Schema + "." + table.Name
+		}
+
+		err := declare(ident, fmt.Sprintf("table %q", tableName))
+		if err != nil {
+			return nil, err
+		}
+		err = declare(ident+"Table", fmt.Sprintf("the name of table %q", tableName))
+		if err != nil {
+			return nil, err
+		}
+		fields := make(map[string]string, len(table.Columns))
+		for _, c := range table.Columns {
+			field := identifier(c.Name)
+			if prev, ok := fields[field]; ok {
+				return nil, errors.Errorf("field %q of column %q of table %q collides with the one of column %q", field, c.Name, tableName, prev)
+			}
+			fields[field] = c.Name
+
+			err = declare(ident+"Column"+field, fmt.Sprintf("column %q of table %q", c.Name, tableName))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		fmt.Fprintf(&body, "\n// %sTable is the name of the %q table.\n", ident, tableName)
+		fmt.Fprintf(&body, "const %sTable string = %q\n", ident, tableName)
+
+		fmt.Fprintf(&body, "\n// Columns of the %q table.\n", tableName)
+		body.WriteString("const (\n")
+		for _, c := range table.Columns {
+			fmt.Fprintf(&body, "\t%sColumn%s string = %q\n", ident, identifier(c.Name), c.Name)
+		}
+		body.WriteString(")\n")
+
+		fmt.Fprintf(&body, "\n// %s is a row of the %q table.\n", ident, tableName)
+		fmt.Fprintf(&body, "type %s struct {\n", ident)
+		for _, c := range table.Columns {
+			t := mapType(c, enums)
+			if t.importPath != "" {
+				imports[t.importPath] = true
+			}
+			fmt.Fprintf(&body, "\t%s %s `db:%q`\n", identifier(c.Name), t.name, c.Name)
+		}
+		body.WriteString("}\n")
+	}
+
+	var buf bytes.Buffer
+	command := opts.Command
+	if command == "" {
+		command = "norm-gen"
+	}
+	fmt.Fprintf(&buf, "// Code generated by %s; DO NOT EDIT.\n\n", command)
+	fmt.Fprintf(&buf, "package %s\n", opts.Package)
+
+	if len(imports) > 0 {
+		paths := make([]string, 0, len(imports))
+		for path := range imports {
+			paths = append(paths, path)
+		}
+		sort.Slice(paths, func(i, j int) bool {
+			if isStdlib(paths[i]) != isStdlib(paths[j]) {
+				return isStdlib(paths[i])
+			}
+			return paths[i] < paths[j]
+		})
+
+		buf.WriteString("\nimport (\n")
+		for i, path := range paths {
+			// Separate the standard library packages from the others
+			if i > 0 && isStdlib(paths[i-1]) && !isStdlib(path) {
+				buf.WriteString("\n")
+			}
+			fmt.Fprintf(&buf, "\t%q\n", path)
+		}
+		buf.WriteString(")\n")
+	}
+	buf.Write(body.Bytes())
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, errors.Wrap(err, "format source")
+	}
+	return src, nil
+}
+
+// isStdlib returns true if the import path belongs to the standard library.
+func isStdlib(path string) bool {
+	return !strings.Contains(strings.SplitN(path, "/", 2)[0], ".")
+}
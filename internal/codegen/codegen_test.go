@@ -0,0 +1,202 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"unknwon.dev/norm/schema"
+)
+
+func TestIdentifier(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "id", want: "ID"},
+		{name: "user_id", want: "UserID"},
+		{name: "team_ids", want: "TeamIDs"},
+		{name: "avatar_url", want: "AvatarURL"},
+		{name: "created_at", want: "CreatedAt"},
+		{name: "users", want: "Users"},
+		{name: "user-emails", want: "UserEmails"},
+		{name: "2fa_enabled", want: "X2faEnabled"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, identifier(test.name))
+		})
+	}
+}
+
+func TestMapType(t *testing.T) {
+	enums := map[string]bool{"mood": true}
+	tests := []struct {
+		typ      string
+		nullable bool
+		want     string
+	}{
+		{typ: "bigint", want: "int64"},
+		{typ: "bigint", nullable: true, want: "*int64"},
+		{typ: "character varying(255)", want: "string"},
+		{typ: "timestamp(3) with time zone", nullable: true, want: "*time.Time"},
+		{typ: "numeric(10,2)", want: "string"},
+		{typ: "bigint[]", want: "types.Int64Array"},
+		{typ: "bigint[]", nullable: true, want: "types.Int64Array"},
+		{typ: "integer[]", want: "types.Int64Array"},
+		{typ: "smallint[]", want: "types.Int64Array"},
+		{typ: "jsonb", nullable: true, want: "[]byte"},
+		{typ: "mood", want: "string"},
+		{typ: "point", want: "interface{}"},
+	}
+	for _, test := range tests {
+		t.Run(test.typ, func(t *testing.T) {
+			got := mapType(&schema.Column{Type: test.typ, Nullable: test.nullable}, enums)
+			assert.Equal(t, test.want, got.name)
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	def := "now()"
+	s := &schema.Schema{
+		Name: "public",
+		Tables: []*schema.Table{
+			{
+				Schema: "public",
+				Name:   "users",
+				Columns: []*schema.Column{
+					{Name: "id", Type: "bigint"},
+					{Name: "email", Type: "text"},
+					{Name: "nickname", Type: "character varying(32)", Nullable: true},
+					{Name: "team_ids", Type: "bigint[]", Nullable: true},
+					{Name: "mood", Type: "mood"},
+					{Name: "created_at", Type: "timestamp with time zone", Default: &def},
+				},
+			},
+			{
+				Schema: "public",
+				Name:   "emails",
+				Columns: []*schema.Column{
+					{Name: "id", Type: "bigint"},
+					{Name: "user_id", Type: "bigint"},
+				},
+			},
+		},
+		Enums: []*schema.Enum{
+			{Schema: "public", Name: "mood", Values: []string{"happy", "sad"}},
+		},
+	}
+
+	got, err := Generate(s, Options{Package: "models"})
+	require.NoError(t, err)
+
+	want := "// Code generated by norm-gen; DO NOT EDIT.\n" + `
+package models
+
+import (
+	"time"
+
+	"unknwon.dev/norm/types"
+)
+
+// EmailsTable is the name of the "emails" table.
+const EmailsTable string = "emails"
+
+// Columns of the "emails" table.
+const (
+	EmailsColumnID     string = "id"
+	EmailsColumnUserID string = "user_id"
+)
+
+// Emails is a row of the "emails" table.
+type Emails struct {
+	ID     int64 ` + "`db:\"id\"`" + `
+	UserID int64 ` + "`db:\"user_id\"`" + `
+}
+
+// UsersTable is the name of the "users" table.
+const UsersTable string = "users"
+
+// Columns of the "users" table.
+const (
+	UsersColumnID        string = "id"
+	UsersColumnEmail     string = "email"
+	UsersColumnNickname  string = "nickname"
+	UsersColumnTeamIDs   string = "team_ids"
+	UsersColumnMood      string = "mood"
+	UsersColumnCreatedAt string = "created_at"
+)
+
+// Users is a row of the "users" table.
+type Users struct {
+	ID        int64            ` + "`db:\"id\"`" + `
+	Email     string           ` + "`db:\"email\"`" + `
+	Nickname  *string          ` + "`db:\"nickname\"`" + `
+	TeamIDs   types.Int64Array ` + "`db:\"team_ids\"`" + `
+	Mood      string           ` + "`db:\"mood\"`" + `
+	CreatedAt time.Time        ` + "`db:\"created_at\"`" + `
+}
+`
+	assert.Equal(t, want, string(got))
+
+	// The output should be deterministic regardless of the order of tables.
+	s.Tables[0], s.Tables[1] = s.Tables[1], s.Tables[0]
+	again, err := Generate(s, Options{Package: "models"})
+	require.NoError(t, err)
+	assert.Equal(t, string(got), string(again))
+}
+
+func TestGenerate_NoPackage(t *testing.T) {
+	_, err := Generate(&schema.Schema{}, Options{})
+	assert.Error(t, err)
+}
+
+func TestGenerate_Collisions(t *testing.T) {
+	tests := []struct {
+		name    string
+		tables  []*schema.Table
+		wantErr string
+	}{
+		{
+			name: "struct and table name",
+			tables: []*schema.Table{
+				{Name: "users"},
+				{Name: "users_table"},
+			},
+			wantErr: `identifier "UsersTable" of table "users_table" collides with the one of the name of table "users"`,
+		},
+		{
+			name: "column name",
+			tables: []*schema.Table{
+				{Name: "users", Columns: []*schema.Column{{Name: "id", Type: "bigint"}}},
+				{Name: "users_column_id"},
+			},
+			wantErr: `identifier "UsersColumnID" of table "users_column_id" collides with the one of column "id" of table "users"`,
+		},
+		{
+			name: "fields",
+			tables: []*schema.Table{
+				{
+					Name: "users",
+					Columns: []*schema.Column{
+						{Name: "user_id", Type: "bigint"},
+						{Name: "UserID", Type: "bigint"},
+					},
+				},
+			},
+			wantErr: `field "UserID" of column "UserID" of table "users" collides with the one of column "user_id"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Generate(&schema.Schema{Tables: test.tables}, Options{Package: "models"})
+			assert.EqualError(t, err, test.wantErr)
+		})
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package exql
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// AlterationType is the type of action in the ALTER TABLE statement.
+type AlterationType uint8
+
+const (
+	_ = AlterationType(iota)
+
+	AlterationAddColumn
+	AlterationAddConstraint
+	AlterationAlterColumnType
+	AlterationDropColumn
+	AlterationDropConstraint
+	AlterationDropNotNull
+	AlterationRenameColumn
+	AlterationSetNotNull
+)
+
+var _ Fragment = (*AlterationFragment)(nil)
+
+// AlterationFragment is an action in the ALTER TABLE statement.
+//
+// NOTE: Fields are public purely for the purpose of being hashable. Direct
+// modifications to them after construction may not take effect depends on
+// whether the hash has been computed.
+type AlterationFragment struct {
+	hash       hash
+	Type       AlterationType
+	Name       string
+	NewName    string
+	Definition Fragment
+}
+
+// AddColumn constructs an AlterationFragment that adds the column with given
+// definition.
+func AddColumn(def *ColumnDefinitionFragment) *AlterationFragment {
+	return &AlterationFragment{
+		Type:       AlterationAddColumn,
+		Definition: def,
+	}
+}
+
+// AddConstraint constructs an AlterationFragment that adds the given table
+// constraint.
+func AddConstraint(c *ConstraintFragment) *AlterationFragment {
+	return &AlterationFragment{
+		Type:       AlterationAddConstraint,
+		Definition: c,
+	}
+}
+
+// AlterColumnType constructs an AlterationFragment that changes the data type
+// of the column with given name.
+func AlterColumnType(name, typ string) *AlterationFragment {
+	return &AlterationFragment{
+		Type:       AlterationAlterColumnType,
+		Name:       name,
+		Definition: Raw(typ),
+	}
+}
+
+// DropColumn constructs an AlterationFragment that drops the column with given
+// name.
+func DropColumn(name string) *AlterationFragment {
+	return &AlterationFragment{
+		Type: AlterationDropColumn,
+		Name: name,
+	}
+}
+
+// DropConstraint constructs an AlterationFragment that drops the table
+// constraint with given name.
+func DropConstraint(name string) *AlterationFragment {
+	return &AlterationFragment{
+		Type: AlterationDropConstraint,
+		Name: name,
+	}
+}
+
+// DropNotNull constructs an AlterationFragment that drops the NOT NULL
+// constraint of the column with given name.
+func DropNotNull(name string) *AlterationFragment {
+	return &AlterationFragment{
+		Type: AlterationDropNotNull,
+		Name: name,
+	}
+}
+
+// RenameColumn constructs an AlterationFragment that renames the column from
+// the name to the new name.
+func RenameColumn(name, newName string) *AlterationFragment {
+	return &AlterationFragment{
+		Type:    AlterationRenameColumn,
+		Name:    name,
+		NewName: newName,
+	}
+}
+
+// SetNotNull constructs an AlterationFragment that sets the NOT NULL constraint
+// of the column with given name.
+func SetNotNull(name string) *AlterationFragment {
+	return &AlterationFragment{
+		Type: AlterationSetNotNull,
+		Name: name,
+	}
+}
+
+func (a *AlterationFragment) Hash() string {
+	return a.hash.Hash(a)
+}
+
+func (a *AlterationFragment) layout() (TemplateLayout, error) {
+	switch a.Type {
+	case AlterationAddColumn:
+		return LayoutAddColumn, nil
+	case AlterationAddConstraint:
+		return LayoutAddConstraint, nil
+	case AlterationAlterColumnType:
+		return LayoutAlterColumnType, nil
+	case AlterationDropColumn:
+		return LayoutDropColumn, nil
+	case AlterationDropConstraint:
+		return LayoutDropConstraint, nil
+	case AlterationDropNotNull:
+		return LayoutDropNotNull, nil
+	case AlterationRenameColumn:
+		return LayoutRenameColumn, nil
+	case AlterationSetNotNull:
+		return LayoutSetNotNull, nil
+	}
+	return LayoutNone, errors.Errorf("unexpected type %v", a.Type)
+}
+
+func (a *AlterationFragment) Compile(t *Template) (compiled string, err error) {
+	if v, ok := t.Get(a); ok {
+		return v, nil
+	}
+
+	layout, err := a.layout()
+	if err != nil {
+		return "", errors.Wrap(err, "get layout")
+	}
+
+	data := make(map[string]string, 3)
+	if a.Name != "" {
+		data["Name"], err = t.Compile(LayoutIdentifierQuote, Raw(a.Name))
+		if err != nil {
+			return "", errors.Wrapf(err, "compile LayoutIdentifierQuote with name %q", a.Name)
+		}
+	}
+	if a.NewName != "" {
+		data["NewName"], err = t.Compile(LayoutIdentifierQuote, Raw(a.NewName))
+		if err != nil {
+			return "", errors.Wrapf(err, "compile LayoutIdentifierQuote with new name %q", a.NewName)
+		}
+	}
+	if a.Definition != nil {
+		data["Definition"], err = a.Definition.Compile(t)
+		if err != nil {
+			return "", errors.Wrap(err, "compile definition")
+		}
+	}
+
+	compiled, err = t.Compile(layout, data)
+	if err != nil {
+		return "", errors.Wrapf(err, "compile %v with data %v", layout, data)
+	}
+
+	t.Set(a, compiled)
+	return compiled, nil
+}
+
+var _ Fragment = (*AlterationsFragment)(nil)
+
+// AlterationsFragment is a list of AlterationFragment.
+//
+// NOTE: Fields are public purely for the purpose of being hashable. Direct
+// modifications to them after construction may not take effect depends on
+// whether the hash has been computed.
+type AlterationsFragment struct {
+	hash        hash
+	Alterations []*AlterationFragment
+}
+
+// Alterations constructs an AlterationsFragment with the given alterations.
+func Alterations(alterations ...*AlterationFragment) *AlterationsFragment {
+	return &AlterationsFragment{
+		Alterations: alterations,
+	}
+}
+
+func (as *AlterationsFragment) Hash() string {
+	return as.hash.Hash(as)
+}
+
+func (as *AlterationsFragment) Compile(t *Template) (compiled string, err error) {
+	if as.Empty() {
+		return "", nil
+	}
+
+	if v, ok := t.Get(as); ok {
+		return v, nil
+	}
+
+	out := make([]string, len(as.Alterations))
+	for i := range as.Alterations {
+		out[i], err = as.Alterations[i].Compile(t)
+		if err != nil {
+			return "", errors.Wrap(err, "compile alteration")
+		}
+	}
+
+	compiled = strings.TrimSpace(strings.Join(out, t.layouts[LayoutIdentifierSeparator]))
+	t.Set(as, compiled)
+	return compiled, nil
+}
+
+// Append appends given alterations to the AlterationsFragment.
+func (as *AlterationsFragment) Append(alterations ...*AlterationFragment) *AlterationsFragment {
+	as.Alterations = append(as.Alterations, alterations...)
+	as.hash.Reset()
+	return as
+}
+
+var _ emptiable = (*AlterationsFragment)(nil)
+
+func (as *AlterationsFragment) Empty() bool {
+	return as == nil || len(as.Alterations) == 0
+}
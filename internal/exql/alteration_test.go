@@ -0,0 +1,100 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package exql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlteration(t *testing.T) {
+	tmpl := defaultTemplate(t)
+
+	t.Run("unexpected type", func(t *testing.T) {
+		_, err := (&AlterationFragment{}).Compile(tmpl)
+		assert.Error(t, err)
+	})
+
+	tests := []struct {
+		name       string
+		alteration *AlterationFragment
+		want       string
+	}{
+		{
+			name:       "add column",
+			alteration: AddColumn(ColumnDefinition("age", "INTEGER", "NOT NULL")),
+			want:       `ADD COLUMN "age" INTEGER NOT NULL`,
+		},
+		{
+			name:       "add constraint",
+			alteration: AddConstraint(Constraint("users_age_check", "CHECK (age > 0)")),
+			want:       `ADD CONSTRAINT "users_age_check" CHECK (age > 0)`,
+		},
+		{
+			name:       "alter column type",
+			alteration: AlterColumnType("age", "BIGINT"),
+			want:       `ALTER COLUMN "age" TYPE BIGINT`,
+		},
+		{
+			name:       "drop column",
+			alteration: DropColumn("age"),
+			want:       `DROP COLUMN "age"`,
+		},
+		{
+			name:       "drop constraint",
+			alteration: DropConstraint("users_age_check"),
+			want:       `DROP CONSTRAINT "users_age_check"`,
+		},
+		{
+			name:       "drop not null",
+			alteration: DropNotNull("age"),
+			want:       `ALTER COLUMN "age" DROP NOT NULL`,
+		},
+		{
+			name:       "rename column",
+			alteration: RenameColumn("age", "years"),
+			want:       `RENAME COLUMN "age" TO "years"`,
+		},
+		{
+			name:       "set not null",
+			alteration: SetNotNull("age"),
+			want:       `ALTER COLUMN "age" SET NOT NULL`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.alteration.Compile(tmpl)
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestAlterations(t *testing.T) {
+	tmpl := defaultTemplate(t)
+
+	t.Run("empty", func(t *testing.T) {
+		got, err := Alterations().Compile(tmpl)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	as := Alterations(DropColumn("age"))
+	as.Append(AddColumn(ColumnDefinition("birthday", "DATE")))
+
+	got, err := as.Compile(tmpl)
+	require.NoError(t, err)
+
+	want := `DROP COLUMN "age", ADD COLUMN "birthday" DATE`
+	assert.Equal(t, want, got)
+
+	t.Run("cache hit", func(t *testing.T) {
+		got, err := as.Compile(tmpl)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+}
@@ -0,0 +1,189 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package exql
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var _ Fragment = (*ColumnDefinitionFragment)(nil)
+
+// ColumnDefinitionFragment is a column definition in the CREATE TABLE or ALTER
+// TABLE statement.
+//
+// NOTE: Fields are public purely for the purpose of being hashable. Direct
+// modifications to them after construction may not take effect depends on
+// whether the hash has been computed.
+type ColumnDefinitionFragment struct {
+	hash        hash
+	Name        string
+	Type        string
+	NotNull     bool
+	Default     Fragment
+	Constraints []string
+}
+
+// ColumnDefinition constructs a ColumnDefinitionFragment with the given name,
+// data type, and list of raw column constraints (e.g. "PRIMARY KEY").
+func ColumnDefinition(name, typ string, constraints ...string) *ColumnDefinitionFragment {
+	return &ColumnDefinitionFragment{
+		Name:        name,
+		Type:        typ,
+		Constraints: constraints,
+	}
+}
+
+func (cd *ColumnDefinitionFragment) Hash() string {
+	return cd.hash.Hash(cd)
+}
+
+func (cd *ColumnDefinitionFragment) Compile(t *Template) (string, error) {
+	if v, ok := t.Get(cd); ok {
+		return v, nil
+	}
+
+	name, err := t.Compile(LayoutIdentifierQuote, Raw(cd.Name))
+	if err != nil {
+		return "", errors.Wrapf(err, "compile LayoutIdentifierQuote with name %q", cd.Name)
+	}
+
+	data := map[string]interface{}{
+		"Name":        name,
+		"Type":        cd.Type,
+		"NotNull":     cd.NotNull,
+		"Constraints": cd.Constraints,
+	}
+	if cd.Default != nil {
+		def, err := cd.Default.Compile(t)
+		if err != nil {
+			return "", errors.Wrap(err, "compile default")
+		}
+		data["Default"] = def
+	}
+
+	compiled, err := t.Compile(LayoutColumnDefinition, data)
+	if err != nil {
+		return "", errors.Wrapf(err, "compile LayoutColumnDefinition with data %v", data)
+	}
+
+	t.Set(cd, compiled)
+	return compiled, nil
+}
+
+var _ Fragment = (*ConstraintFragment)(nil)
+
+// ConstraintFragment is a table constraint in the CREATE TABLE or ALTER TABLE
+// statement.
+//
+// NOTE: Fields are public purely for the purpose of being hashable. Direct
+// modifications to them after construction may not take effect depends on
+// whether the hash has been computed.
+type ConstraintFragment struct {
+	hash       hash
+	Name       string
+	Definition string
+}
+
+// Constraint constructs a ConstraintFragment with the given name and raw
+// definition (e.g. "UNIQUE (email)"). The name can be empty to let the database
+// generate one.
+func Constraint(name, definition string) *ConstraintFragment {
+	return &ConstraintFragment{
+		Name:       name,
+		Definition: definition,
+	}
+}
+
+func (c *ConstraintFragment) Hash() string {
+	return c.hash.Hash(c)
+}
+
+func (c *ConstraintFragment) Compile(t *Template) (compiled string, err error) {
+	if v, ok := t.Get(c); ok {
+		return v, nil
+	}
+
+	var name string
+	if c.Name != "" {
+		name, err = t.Compile(LayoutIdentifierQuote, Raw(c.Name))
+		if err != nil {
+			return "", errors.Wrapf(err, "compile LayoutIdentifierQuote with name %q", c.Name)
+		}
+	}
+
+	data := map[string]string{
+		"Name":       name,
+		"Definition": c.Definition,
+	}
+	compiled, err = t.Compile(LayoutConstraint, data)
+	if err != nil {
+		return "", errors.Wrapf(err, "compile LayoutConstraint with data %v", data)
+	}
+
+	t.Set(c, compiled)
+	return compiled, nil
+}
+
+var _ Fragment = (*DefinitionsFragment)(nil)
+
+// DefinitionsFragment is a list of column definitions and table constraints in
+// the CREATE TABLE statement.
+//
+// NOTE: Fields are public purely for the purpose of being hashable. Direct
+// modifications to them after construction may not take effect depends on
+// whether the hash has been computed.
+type DefinitionsFragment struct {
+	hash        hash
+	Definitions []Fragment
+}
+
+// Definitions constructs a DefinitionsFragment with the given column
+// definitions and table constraints.
+func Definitions(defs ...Fragment) *DefinitionsFragment {
+	return &DefinitionsFragment{
+		Definitions: defs,
+	}
+}
+
+func (ds *DefinitionsFragment) Hash() string {
+	return ds.hash.Hash(ds)
+}
+
+func (ds *DefinitionsFragment) Compile(t *Template) (compiled string, err error) {
+	if ds.Empty() {
+		return "", nil
+	}
+
+	if v, ok := t.Get(ds); ok {
+		return v, nil
+	}
+
+	out := make([]string, len(ds.Definitions))
+	for i := range ds.Definitions {
+		out[i], err = ds.Definitions[i].Compile(t)
+		if err != nil {
+			return "", errors.Wrap(err, "compile definition")
+		}
+	}
+
+	compiled = strings.TrimSpace(strings.Join(out, t.layouts[LayoutIdentifierSeparator]))
+	t.Set(ds, compiled)
+	return compiled, nil
+}
+
+// Append appends given definitions to the DefinitionsFragment.
+func (ds *DefinitionsFragment) Append(defs ...Fragment) *DefinitionsFragment {
+	ds.Definitions = append(ds.Definitions, defs...)
+	ds.hash.Reset()
+	return ds
+}
+
+var _ emptiable = (*DefinitionsFragment)(nil)
+
+func (ds *DefinitionsFragment) Empty() bool {
+	return ds == nil || len(ds.Definitions) == 0
+}
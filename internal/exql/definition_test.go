@@ -0,0 +1,95 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package exql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumnDefinition(t *testing.T) {
+	tmpl := defaultTemplate(t)
+
+	tests := []struct {
+		name string
+		def  *ColumnDefinitionFragment
+		want string
+	}{
+		{
+			name: "type only",
+			def:  ColumnDefinition("name", "TEXT"),
+			want: `"name" TEXT`,
+		},
+		{
+			name: "constraints",
+			def:  ColumnDefinition("id", "BIGSERIAL", "PRIMARY KEY"),
+			want: `"id" BIGSERIAL PRIMARY KEY`,
+		},
+		{
+			name: "not null and default",
+			def: &ColumnDefinitionFragment{
+				Name:        "created_at",
+				Type:        "TIMESTAMPTZ",
+				NotNull:     true,
+				Default:     Raw("NOW()"),
+				Constraints: []string{"CHECK (created_at > '2000-01-01')"},
+			},
+			want: `"created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW() CHECK (created_at > '2000-01-01')`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.def.Compile(tmpl)
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestConstraint(t *testing.T) {
+	tmpl := defaultTemplate(t)
+
+	t.Run("unnamed", func(t *testing.T) {
+		got, err := Constraint("", "UNIQUE (email)").Compile(tmpl)
+		require.NoError(t, err)
+		assert.Equal(t, `UNIQUE (email)`, got)
+	})
+
+	t.Run("named", func(t *testing.T) {
+		got, err := Constraint("users_email_key", "UNIQUE (email)").Compile(tmpl)
+		require.NoError(t, err)
+		assert.Equal(t, `CONSTRAINT "users_email_key" UNIQUE (email)`, got)
+	})
+}
+
+func TestDefinitions(t *testing.T) {
+	tmpl := defaultTemplate(t)
+
+	t.Run("empty", func(t *testing.T) {
+		got, err := Definitions().Compile(tmpl)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	ds := Definitions(
+		ColumnDefinition("id", "BIGINT"),
+		ColumnDefinition("email", "TEXT"),
+	)
+	ds.Append(Constraint("", "PRIMARY KEY (id)"))
+
+	got, err := ds.Compile(tmpl)
+	require.NoError(t, err)
+
+	want := `"id" BIGINT, "email" TEXT, PRIMARY KEY (id)`
+	assert.Equal(t, want, got)
+
+	t.Run("cache hit", func(t *testing.T) {
+		got, err := ds.Compile(tmpl)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+}
@@ -15,11 +15,20 @@ type StatementType uint
 const (
 	_ = StatementType(iota)
 
+	StatementAlterTable
 	StatementCount
+	StatementCreateIndex
+	StatementCreateTable
+	StatementCreateView
 	StatementDelete
 	StatementDropDatabase
+	StatementDropIndex
 	StatementDropTable
 	StatementInsert
+	StatementRefreshMaterializedView
+	StatementReleaseSavepoint
+	StatementRollbackToSavepoint
+	StatementSavepoint
 	StatementSelect
 	StatementTruncate
 	StatementUpdate
@@ -27,6 +36,48 @@ const (
 	StatementSQL
 )
 
+// String returns the SQL command of the statement type, e.g. "SELECT" and
+// "CREATE TABLE", or "SQL" for raw SQL.
+func (t StatementType) String() string {
+	switch t {
+	case StatementAlterTable:
+		return "ALTER TABLE"
+	case StatementCount, StatementSelect:
+		return "SELECT"
+	case StatementCreateIndex:
+		return "CREATE INDEX"
+	case StatementCreateTable:
+		return "CREATE TABLE"
+	case StatementCreateView:
+		return "CREATE VIEW"
+	case StatementDelete:
+		return "DELETE"
+	case StatementDropDatabase:
+		return "DROP DATABASE"
+	case StatementDropIndex:
+		return "DROP INDEX"
+	case StatementDropTable:
+		return "DROP TABLE"
+	case StatementInsert:
+		return "INSERT"
+	case StatementRefreshMaterializedView:
+		return "REFRESH MATERIALIZED VIEW"
+	case StatementReleaseSavepoint:
+		return "RELEASE SAVEPOINT"
+	case StatementRollbackToSavepoint:
+		return "ROLLBACK TO SAVEPOINT"
+	case StatementSavepoint:
+		return "SAVEPOINT"
+	case StatementTruncate:
+		return "TRUNCATE"
+	case StatementUpdate:
+		return "UPDATE"
+	case StatementSQL:
+		return "SQL"
+	}
+	return ""
+}
+
 var _ Fragment = (*Statement)(nil)
 
 // Statement is an AST for constructing SQL statements.
@@ -40,6 +91,7 @@ type Statement struct {
 	Type         StatementType
 	Database     *DatabaseFragment
 	Table        Fragment
+	Index        Fragment
 	Columns      Fragment
 	Values       Fragment
 	Distinct     bool
@@ -49,6 +101,19 @@ type Statement struct {
 	Joins        Fragment
 	Where        *WhereFragment
 	Returning    *ReturningFragment
+	Definitions  *DefinitionsFragment
+	Alterations  *AlterationsFragment
+	Query        Fragment
+	Savepoint    Fragment
+
+	IfExists        bool
+	IfNotExists     bool
+	Cascade         bool
+	RestartIdentity bool
+	Unique          bool
+	Concurrently    bool
+	Materialized    bool
+	OrReplace       bool
 
 	Limit  int
 	Offset int
@@ -73,18 +138,57 @@ func (s *Statement) Amend(in string) string {
 	return s.amendFn(in)
 }
 
+// TableName returns the name of the table that the statement applies to, which
+// is the first one when there are multiple tables. It returns an empty string
+// when the statement has no table or the name is not a string, e.g. a subquery.
+func (s *Statement) TableName() string {
+	var table *TableFragment
+	switch t := s.Table.(type) {
+	case *TableFragment:
+		table = t
+	case *TablesFragment:
+		if len(t.Tables) > 0 {
+			table = t.Tables[0]
+		}
+	}
+	if table == nil {
+		return ""
+	}
+
+	name, _, _ := table.NameAndAlias()
+	return name
+}
+
 func (s *Statement) layout() (TemplateLayout, error) {
 	switch s.Type {
+	case StatementAlterTable:
+		return LayoutAlterTable, nil
 	case StatementCount:
 		return LayoutCount, nil
+	case StatementCreateIndex:
+		return LayoutCreateIndex, nil
+	case StatementCreateTable:
+		return LayoutCreateTable, nil
+	case StatementCreateView:
+		return LayoutCreateView, nil
 	case StatementDelete:
 		return LayoutDelete, nil
 	case StatementDropDatabase:
 		return LayoutDropDatabase, nil
+	case StatementDropIndex:
+		return LayoutDropIndex, nil
 	case StatementDropTable:
 		return LayoutDropTable, nil
 	case StatementInsert:
 		return LayoutInsert, nil
+	case StatementRefreshMaterializedView:
+		return LayoutRefreshMaterializedView, nil
+	case StatementReleaseSavepoint:
+		return LayoutReleaseSavepoint, nil
+	case StatementRollbackToSavepoint:
+		return LayoutRollbackToSavepoint, nil
+	case StatementSavepoint:
+		return LayoutSavepoint, nil
 	case StatementSelect:
 		return LayoutSelect, nil
 	case StatementTruncate:
@@ -26,6 +26,96 @@ func TestStatement(t *testing.T) {
 		statement *Statement
 		want      string
 	}{
+		{
+			name: "alter table",
+			statement: &Statement{
+				Type:  StatementAlterTable,
+				Table: Table("users"),
+				Alterations: Alterations(
+					AddColumn(ColumnDefinition("age", "INTEGER")),
+					DropColumn("nickname"),
+				),
+			},
+			want: `ALTER TABLE "users" ADD COLUMN "age" INTEGER, DROP COLUMN "nickname"`,
+		},
+		{
+			name: "alter table if exists",
+			statement: &Statement{
+				Type:        StatementAlterTable,
+				Table:       Table("users"),
+				Alterations: Alterations(RenameColumn("age", "years")),
+				IfExists:    true,
+			},
+			want: `ALTER TABLE IF EXISTS "users" RENAME COLUMN "age" TO "years"`,
+		},
+		{
+			name: "create index",
+			statement: &Statement{
+				Type:    StatementCreateIndex,
+				Index:   Table("users_email_idx"),
+				Table:   Table("users"),
+				Columns: Columns(Column("email")),
+			},
+			want: `CREATE INDEX "users_email_idx" ON "users" ("email")`,
+		},
+		{
+			name: "create unique index concurrently if not exists with where",
+			statement: &Statement{
+				Type:         StatementCreateIndex,
+				Index:        Table("users_email_idx"),
+				Table:        Table("users"),
+				Columns:      Columns(Column("email"), Column(Raw("lower(name)"))),
+				Where:        Where(Raw("deleted_at IS NULL")),
+				Unique:       true,
+				Concurrently: true,
+				IfNotExists:  true,
+			},
+			want: `CREATE UNIQUE INDEX CONCURRENTLY IF NOT EXISTS "users_email_idx" ON "users" ("email", lower(name)) WHERE deleted_at IS NULL`,
+		},
+		{
+			name: "create table",
+			statement: &Statement{
+				Type:  StatementCreateTable,
+				Table: Table("users"),
+				Definitions: Definitions(
+					ColumnDefinition("id", "BIGSERIAL", "PRIMARY KEY"),
+					ColumnDefinition("email", "TEXT"),
+					Constraint("users_email_key", "UNIQUE (email)"),
+				),
+			},
+			want: `CREATE TABLE "users" ("id" BIGSERIAL PRIMARY KEY, "email" TEXT, CONSTRAINT "users_email_key" UNIQUE (email))`,
+		},
+		{
+			name: "create table if not exists",
+			statement: &Statement{
+				Type:        StatementCreateTable,
+				Table:       Table("public.users"),
+				Definitions: Definitions(ColumnDefinition("id", "BIGINT")),
+				IfNotExists: true,
+			},
+			want: `CREATE TABLE IF NOT EXISTS "public"."users" ("id" BIGINT)`,
+		},
+		{
+			name: "create view",
+			statement: &Statement{
+				Type:      StatementCreateView,
+				Table:     Table("active_users"),
+				Query:     Raw(`SELECT * FROM "users" WHERE active`),
+				OrReplace: true,
+			},
+			want: `CREATE OR REPLACE VIEW "active_users" AS SELECT * FROM "users" WHERE active`,
+		},
+		{
+			name: "create materialized view if not exists",
+			statement: &Statement{
+				Type:         StatementCreateView,
+				Table:        Table("user_stats"),
+				Query:        Raw(`SELECT COUNT(*) FROM "users"`),
+				Materialized: true,
+				IfNotExists:  true,
+			},
+			want: `CREATE MATERIALIZED VIEW IF NOT EXISTS "user_stats" AS SELECT COUNT(*) FROM "users"`,
+		},
 		{
 			name: "delete",
 			statement: &Statement{
@@ -45,6 +135,34 @@ func TestStatement(t *testing.T) {
 			},
 			want: `DROP DATABASE "norm"`,
 		},
+		{
+			name: "drop database if exists",
+			statement: &Statement{
+				Type:     StatementDropDatabase,
+				Database: Database("norm"),
+				IfExists: true,
+			},
+			want: `DROP DATABASE IF EXISTS "norm"`,
+		},
+		{
+			name: "drop index",
+			statement: &Statement{
+				Type:  StatementDropIndex,
+				Index: Table("users_email_idx"),
+			},
+			want: `DROP INDEX "users_email_idx"`,
+		},
+		{
+			name: "drop index concurrently if exists cascade",
+			statement: &Statement{
+				Type:         StatementDropIndex,
+				Index:        Table("users_email_idx"),
+				Concurrently: true,
+				IfExists:     true,
+				Cascade:      true,
+			},
+			want: `DROP INDEX CONCURRENTLY IF EXISTS "users_email_idx" CASCADE`,
+		},
 		{
 			name: "drop table",
 			statement: &Statement{
@@ -53,6 +171,57 @@ func TestStatement(t *testing.T) {
 			},
 			want: `DROP TABLE "users"`,
 		},
+		{
+			name: "drop table if exists cascade",
+			statement: &Statement{
+				Type:     StatementDropTable,
+				Table:    Tables(Table("users"), Table("emails")),
+				IfExists: true,
+				Cascade:  true,
+			},
+			want: `DROP TABLE IF EXISTS "users", "emails" CASCADE`,
+		},
+		{
+			name: "refresh materialized view",
+			statement: &Statement{
+				Type:  StatementRefreshMaterializedView,
+				Table: Table("user_stats"),
+			},
+			want: `REFRESH MATERIALIZED VIEW "user_stats"`,
+		},
+		{
+			name: "refresh materialized view concurrently",
+			statement: &Statement{
+				Type:         StatementRefreshMaterializedView,
+				Table:        Table("user_stats"),
+				Concurrently: true,
+			},
+			want: `REFRESH MATERIALIZED VIEW CONCURRENTLY "user_stats"`,
+		},
+		{
+			name: "release savepoint",
+			statement: &Statement{
+				Type:      StatementReleaseSavepoint,
+				Savepoint: Table("sp_1"),
+			},
+			want: `RELEASE SAVEPOINT "sp_1"`,
+		},
+		{
+			name: "rollback to savepoint",
+			statement: &Statement{
+				Type:      StatementRollbackToSavepoint,
+				Savepoint: Table("sp_1"),
+			},
+			want: `ROLLBACK TO SAVEPOINT "sp_1"`,
+		},
+		{
+			name: "savepoint",
+			statement: &Statement{
+				Type:      StatementSavepoint,
+				Savepoint: Table("sp_1"),
+			},
+			want: `SAVEPOINT "sp_1"`,
+		},
 		{
 			name: "truncate table",
 			statement: &Statement{
@@ -61,6 +230,16 @@ func TestStatement(t *testing.T) {
 			},
 			want: `TRUNCATE TABLE "users"`,
 		},
+		{
+			name: "truncate table restart identity cascade",
+			statement: &Statement{
+				Type:            StatementTruncate,
+				Table:           Table("users"),
+				RestartIdentity: true,
+				Cascade:         true,
+			},
+			want: `TRUNCATE TABLE "users" RESTART IDENTITY CASCADE`,
+		},
 		{
 			name: "update",
 			statement: &Statement{
@@ -503,6 +682,40 @@ func TestStatement_Amend(t *testing.T) {
 	assert.Equal(t, want, StripWhitespace(got))
 }
 
+func TestStatement_TableName(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement *Statement
+		want      string
+	}{
+		{
+			name:      "no table",
+			statement: RawSQL(`SELECT 1`),
+			want:      "",
+		},
+		{
+			name:      "table",
+			statement: &Statement{Type: StatementInsert, Table: Table("users")},
+			want:      "users",
+		},
+		{
+			name:      "tables with alias",
+			statement: &Statement{Type: StatementSelect, Table: Tables(Table(Column("users AS u")), Table("emails"))},
+			want:      "users",
+		},
+		{
+			name:      "subquery",
+			statement: &Statement{Type: StatementSelect, Table: Table(Raw(`(SELECT 1)`))},
+			want:      "",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, test.statement.TableName())
+		})
+	}
+}
+
 func TestRawSQL(t *testing.T) {
 	const sql = `SELECT * FROM "foo" ORDER BY "bar"`
 	s := RawSQL(sql)
@@ -39,6 +39,35 @@ func Table(name interface{}) *TableFragment {
 	}
 }
 
+// NameAndAlias returns the name and the alias of the table when its name is a
+// string or a column with a string name, where the alias is recognized in the
+// same way as Table.
+func (t *TableFragment) NameAndAlias() (name, alias string, ok bool) {
+	n, alias := t.Name, t.Alias
+	if c, isColumn := n.(*ColumnFragment); isColumn {
+		n = c.Name
+		if c.Alias != "" {
+			alias = c.Alias
+		}
+	}
+	v, ok := n.(string)
+	if !ok {
+		return "", "", false
+	}
+
+	input := trimString(v)
+	chunks := separateByAS(input)
+	if len(chunks) == 1 {
+		chunks = separateBySpace(input)
+	}
+
+	name = chunks[0]
+	if len(chunks) > 1 {
+		alias = trimString(chunks[1])
+	}
+	return name, alias, true
+}
+
 func (t *TableFragment) Hash() string {
 	return t.hash.Hash(t)
 }
@@ -87,6 +87,63 @@ func TestTable(t *testing.T) {
 	})
 }
 
+func TestTableFragment_NameAndAlias(t *testing.T) {
+	tests := []struct {
+		name      string
+		table     *TableFragment
+		wantName  string
+		wantAlias string
+		wantOK    bool
+	}{
+		{
+			name:     "normal",
+			table:    Table("users"),
+			wantName: "users",
+			wantOK:   true,
+		},
+		{
+			name:      "explicit as",
+			table:     Table("users AS u"),
+			wantName:  "users",
+			wantAlias: "u",
+			wantOK:    true,
+		},
+		{
+			name:      "implicit as",
+			table:     Table("public.users u"),
+			wantName:  "public.users",
+			wantAlias: "u",
+			wantOK:    true,
+		},
+		{
+			name:      "alias field",
+			table:     &TableFragment{Name: "users", Alias: "u"},
+			wantName:  "users",
+			wantAlias: "u",
+			wantOK:    true,
+		},
+		{
+			name:      "column",
+			table:     Table(Column("users u")),
+			wantName:  "users",
+			wantAlias: "u",
+			wantOK:    true,
+		},
+		{
+			name:  "fragment",
+			table: Table(Raw("users")),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			name, alias, ok := test.table.NameAndAlias()
+			assert.Equal(t, test.wantName, name)
+			assert.Equal(t, test.wantAlias, alias)
+			assert.Equal(t, test.wantOK, ok)
+		})
+	}
+}
+
 func TestTables(t *testing.T) {
 	tmpl := defaultTemplate(t)
 
@@ -23,18 +23,31 @@ type TemplateLayout uint8
 const (
 	LayoutNone = TemplateLayout(iota)
 
+	LayoutAddColumn
+	LayoutAddConstraint
+	LayoutAlterColumnType
+	LayoutAlterTable
 	LayoutAndKeyword
 	LayoutAscKeyword
 	LayoutAssignmentOperator
 	LayoutClauseGroup
 	LayoutClauseOperator
 	LayoutColumnAlias
+	LayoutColumnDefinition
 	LayoutColumnSeparator
 	LayoutColumnValue
+	LayoutConstraint
 	LayoutCount
+	LayoutCreateIndex
+	LayoutCreateTable
+	LayoutCreateView
 	LayoutDelete
 	LayoutDescKeyword
+	LayoutDropColumn
+	LayoutDropConstraint
 	LayoutDropDatabase
+	LayoutDropIndex
+	LayoutDropNotNull
 	LayoutDropTable
 	LayoutGroupBy
 	LayoutIdentifierQuote
@@ -44,8 +57,14 @@ const (
 	LayoutOn
 	LayoutOrKeyword
 	LayoutOrderBy
+	LayoutRefreshMaterializedView
+	LayoutReleaseSavepoint
+	LayoutRenameColumn
 	LayoutReturning
+	LayoutRollbackToSavepoint
+	LayoutSavepoint
 	LayoutSelect
+	LayoutSetNotNull
 	LayoutSortByColumn
 	LayoutTableAlias
 	LayoutTruncate
@@ -151,14 +170,23 @@ func (t *Template) Compile(layout TemplateLayout, data interface{}) (string, err
 // DefaultTemplate returns a template that uses PostgreSQL's syntax.
 func DefaultTemplate() (*Template, error) {
 	const (
+		defaultAddColumn       = `ADD COLUMN {{.Definition}}`
+		defaultAddConstraint   = `ADD {{.Definition}}`
+		defaultAlterColumnType = `ALTER COLUMN {{.Name}} TYPE {{.Definition}}`
+		defaultAlterTable      = `
+ALTER TABLE {{if .IfExists}}IF EXISTS {{end}}{{.Table | compile}}
+  {{.Alterations | compile}}
+`
 		defaultAndKeyword         = `AND`
 		defaultAscKeyword         = `ASC`
 		defaultAssignmentOperator = `=`
 		defaultClauseGroup        = `({{.}})`
 		defaultClauseOperator     = ` {{.}} `
 		defaultColumnAlias        = `{{.Name}}{{if .Alias}} AS {{.Alias}}{{end}}`
+		defaultColumnDefinition   = `{{.Name}} {{.Type}}{{if .NotNull}} NOT NULL{{end}}{{if .Default}} DEFAULT {{.Default}}{{end}}{{range .Constraints}} {{.}}{{end}}`
 		defaultColumnSeparator    = `.`
 		defaultColumnValue        = `{{.Column}} {{.Operator}} {{.Value}}`
+		defaultConstraint         = `{{if .Name}}CONSTRAINT {{.Name}} {{end}}{{.Definition}}`
 		defaultCount              = `
 SELECT
   COUNT(*)
@@ -172,16 +200,34 @@ FROM {{.Table | compile}}
   {{if .Offset}}
 	OFFSET {{.Offset}}
   {{end}}
+`
+		defaultCreateIndex = `
+CREATE {{if .Unique}}UNIQUE {{end}}INDEX {{if .Concurrently}}CONCURRENTLY {{end}}{{if .IfNotExists}}IF NOT EXISTS {{end}}{{.Index | compile}}
+  ON {{.Table | compile}} ({{.Columns | compile}})
+{{.Where | compile}}
+`
+		defaultCreateTable = `
+CREATE TABLE {{if .IfNotExists}}IF NOT EXISTS {{end}}{{.Table | compile}} (
+  {{.Definitions | compile}}
+)
+`
+		defaultCreateView = `
+CREATE {{if .OrReplace}}OR REPLACE {{end}}{{if .Materialized}}MATERIALIZED {{end}}VIEW {{if .IfNotExists}}IF NOT EXISTS {{end}}{{.Table | compile}}
+AS {{.Query | compile}}
 `
 		defaultDelete = `
 DELETE FROM {{.Table | compile}}
 {{.Where | compile}}
 {{.Returning | compile}}
 `
-		defaultDescKeyword  = `DESC`
-		defaultDropDatabase = `DROP DATABASE {{.Database | compile}}`
-		defaultDropTable    = `DROP TABLE {{.Table | compile}}`
-		defaultGroupBy      = `
+		defaultDescKeyword    = `DESC`
+		defaultDropColumn     = `DROP COLUMN {{.Name}}`
+		defaultDropConstraint = `DROP CONSTRAINT {{.Name}}`
+		defaultDropDatabase   = `DROP DATABASE {{if .IfExists}}IF EXISTS {{end}}{{.Database | compile}}`
+		defaultDropIndex      = `DROP INDEX {{if .Concurrently}}CONCURRENTLY {{end}}{{if .IfExists}}IF EXISTS {{end}}{{.Index | compile}}{{if .Cascade}} CASCADE{{end}}`
+		defaultDropNotNull    = `ALTER COLUMN {{.Name}} DROP NOT NULL`
+		defaultDropTable      = `DROP TABLE {{if .IfExists}}IF EXISTS {{end}}{{.Table | compile}}{{if .Cascade}} CASCADE{{end}}`
+		defaultGroupBy        = `
 {{if .Columns}}
   GROUP BY {{.Columns}}
 {{end}}
@@ -225,12 +271,17 @@ VALUES
   ORDER BY {{.Columns}}
 {{end}}
 `
-		defaultReturning = `
+		defaultRefreshMaterializedView = `REFRESH MATERIALIZED VIEW {{if .Concurrently}}CONCURRENTLY {{end}}{{.Table | compile}}`
+		defaultReleaseSavepoint        = `RELEASE SAVEPOINT {{.Savepoint | compile}}`
+		defaultRenameColumn            = `RENAME COLUMN {{.Name}} TO {{.NewName}}`
+		defaultReturning               = `
 {{if .Columns}}
   RETURNING {{.Columns}}
 {{end}}
 `
-		defaultSelect = `
+		defaultRollbackToSavepoint = `ROLLBACK TO SAVEPOINT {{.Savepoint | compile}}`
+		defaultSavepoint           = `SAVEPOINT {{.Savepoint | compile}}`
+		defaultSelect              = `
 SELECT
   {{if .Distinct}}
 	DISTINCT
@@ -262,9 +313,10 @@ SELECT
 	OFFSET {{.Offset}}
   {{end}}
 `
+		defaultSetNotNull   = `ALTER COLUMN {{.Name}} SET NOT NULL`
 		defaultSortByColumn = `{{.Column}} {{.Order}}`
 		defaultTableAlias   = `{{.Name}}{{if .Alias}} AS {{.Alias}}{{end}}`
-		defaultTruncate     = `TRUNCATE TABLE {{.Table | compile}}`
+		defaultTruncate     = `TRUNCATE TABLE {{.Table | compile}}{{if .RestartIdentity}} RESTART IDENTITY{{end}}{{if .Cascade}} CASCADE{{end}}`
 		defaultUpdate       = `
 UPDATE
   {{.Table | compile}}
@@ -289,37 +341,56 @@ SET
 
 	tmpl, err := NewTemplate(
 		map[TemplateLayout]string{
-			LayoutAndKeyword:          defaultAndKeyword,
-			LayoutAscKeyword:          defaultAscKeyword,
-			LayoutAssignmentOperator:  defaultAssignmentOperator,
-			LayoutClauseGroup:         defaultClauseGroup,
-			LayoutClauseOperator:      defaultClauseOperator,
-			LayoutColumnAlias:         defaultColumnAlias,
-			LayoutColumnSeparator:     defaultColumnSeparator,
-			LayoutColumnValue:         defaultColumnValue,
-			LayoutCount:               defaultCount,
-			LayoutDelete:              defaultDelete,
-			LayoutDescKeyword:         defaultDescKeyword,
-			LayoutDropDatabase:        defaultDropDatabase,
-			LayoutDropTable:           defaultDropTable,
-			LayoutGroupBy:             defaultGroupBy,
-			LayoutIdentifierQuote:     defaultIdentifierQuote,
-			LayoutIdentifierSeparator: defaultIdentifierSeparator,
-			LayoutInsert:              defaultInsert,
-			LayoutJoin:                defaultJoin,
-			LayoutOn:                  defaultOn,
-			LayoutOrKeyword:           defaultOrKeyword,
-			LayoutOrderBy:             defaultOrderBy,
-			LayoutReturning:           defaultReturning,
-			LayoutSelect:              defaultSelect,
-			LayoutSortByColumn:        defaultSortByColumn,
-			LayoutTableAlias:          defaultTableAlias,
-			LayoutTruncate:            defaultTruncate,
-			LayoutUpdate:              defaultUpdate,
-			LayoutUsing:               defaultUsing,
-			LayoutValueQuote:          defaultValueQuote,
-			LayoutValueSeparator:      defaultValueSeparator,
-			LayoutWhere:               defaultWhere,
+			LayoutAddColumn:               defaultAddColumn,
+			LayoutAddConstraint:           defaultAddConstraint,
+			LayoutAlterColumnType:         defaultAlterColumnType,
+			LayoutAlterTable:              defaultAlterTable,
+			LayoutAndKeyword:              defaultAndKeyword,
+			LayoutAscKeyword:              defaultAscKeyword,
+			LayoutAssignmentOperator:      defaultAssignmentOperator,
+			LayoutClauseGroup:             defaultClauseGroup,
+			LayoutClauseOperator:          defaultClauseOperator,
+			LayoutColumnAlias:             defaultColumnAlias,
+			LayoutColumnDefinition:        defaultColumnDefinition,
+			LayoutColumnSeparator:         defaultColumnSeparator,
+			LayoutColumnValue:             defaultColumnValue,
+			LayoutConstraint:              defaultConstraint,
+			LayoutCount:                   defaultCount,
+			LayoutCreateIndex:             defaultCreateIndex,
+			LayoutCreateTable:             defaultCreateTable,
+			LayoutCreateView:              defaultCreateView,
+			LayoutDelete:                  defaultDelete,
+			LayoutDescKeyword:             defaultDescKeyword,
+			LayoutDropColumn:              defaultDropColumn,
+			LayoutDropConstraint:          defaultDropConstraint,
+			LayoutDropDatabase:            defaultDropDatabase,
+			LayoutDropIndex:               defaultDropIndex,
+			LayoutDropNotNull:             defaultDropNotNull,
+			LayoutDropTable:               defaultDropTable,
+			LayoutGroupBy:                 defaultGroupBy,
+			LayoutIdentifierQuote:         defaultIdentifierQuote,
+			LayoutIdentifierSeparator:     defaultIdentifierSeparator,
+			LayoutInsert:                  defaultInsert,
+			LayoutJoin:                    defaultJoin,
+			LayoutOn:                      defaultOn,
+			LayoutOrKeyword:               defaultOrKeyword,
+			LayoutOrderBy:                 defaultOrderBy,
+			LayoutRefreshMaterializedView: defaultRefreshMaterializedView,
+			LayoutReleaseSavepoint:        defaultReleaseSavepoint,
+			LayoutRenameColumn:            defaultRenameColumn,
+			LayoutReturning:               defaultReturning,
+			LayoutRollbackToSavepoint:     defaultRollbackToSavepoint,
+			LayoutSavepoint:               defaultSavepoint,
+			LayoutSelect:                  defaultSelect,
+			LayoutSetNotNull:              defaultSetNotNull,
+			LayoutSortByColumn:            defaultSortByColumn,
+			LayoutTableAlias:              defaultTableAlias,
+			LayoutTruncate:                defaultTruncate,
+			LayoutUpdate:                  defaultUpdate,
+			LayoutUsing:                   defaultUsing,
+			LayoutValueQuote:              defaultValueQuote,
+			LayoutValueSeparator:          defaultValueSeparator,
+			LayoutWhere:                   defaultWhere,
 		},
 		map[expr.ComparisonOperator]string{
 			expr.ComparisonEqual:    "=",
@@ -30,7 +30,7 @@ func TestTemplate(t *testing.T) {
 		assert.Equal(t, "{{.}}", got)
 
 		got = tmpl.Layout(LayoutOn)
-		assert.Equal(t, "<undefined layout 19>", got)
+		assert.Equal(t, "<undefined layout 32>", got)
 	})
 
 	t.Run("operator", func(t *testing.T) {
@@ -0,0 +1,38 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package reflectx
+
+import (
+	"database/sql"
+	"reflect"
+)
+
+var scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+
+// IsScanner returns true if the Go type or its pointer implements sql.Scanner.
+func IsScanner(t reflect.Type) bool {
+	return t.Implements(scannerType) || reflect.PtrTo(t).Implements(scannerType)
+}
+
+// IsColumn returns true if the field is mapped to a column as a whole, i.e. it
+// is not a struct with mapped fields (unless it implements sql.Scanner), nor a
+// field of a struct that implements sql.Scanner.
+func IsColumn(fi *FieldInfo) bool {
+	for p := fi.Parent; p != nil && p.Field.Type != nil; p = p.Parent {
+		if IsScanner(p.Field.Type) {
+			return false
+		}
+	}
+
+	if IsScanner(fi.Field.Type) {
+		return true
+	}
+	for _, child := range fi.Children {
+		if child != nil {
+			return false
+		}
+	}
+	return true
+}
@@ -265,9 +265,20 @@ func methodName() string {
 }
 
 type typeQueue struct {
-	t          reflect.Type
-	fi         *FieldInfo
-	parentPath string
+	t  reflect.Type
+	fi *FieldInfo
+	// pathPrefix is the prefix of paths of the fields, e.g. "parent." for nested
+	// fields or the value of the "prefix" option.
+	pathPrefix string
+}
+
+// childPathPrefix returns the prefix of paths of the fields nested in the field
+// with given path.
+func childPathPrefix(path string) string {
+	if path == "" {
+		return ""
+	}
+	return path + "."
 }
 
 // copyAndAppend is a copying `append` that creates a new slice each time.
@@ -316,22 +327,26 @@ func parseName(field reflect.StructField, tagName string, mapFunc, tagMapFunc ma
 		tag = tagMapFunc(tag)
 	}
 
-	// Finally, split the options from the name
+	// Finally, split the options from the name. The field name is kept when the
+	// tag of a non-embedded field only has options, e.g. `db:",required"`.
 	parts := strings.Split(tag, ",")
-	fieldName = parts[0]
+	if parts[0] != "" || len(parts) == 1 || field.Anonymous {
+		fieldName = parts[0]
+	}
 
 	return tag, fieldName
 }
 
-// parseOptions parses options out of a tag string, skipping the name.
+// parseOptions parses options out of a tag string, skipping the name. Commas
+// within parentheses do not separate options, e.g. "type=numeric(10,2)".
 func parseOptions(tag string) map[string]string {
-	parts := strings.Split(tag, ",")
+	parts := splitTag(tag)
 	options := make(map[string]string, len(parts))
 	if len(parts) > 1 {
 		for _, opt := range parts[1:] {
 			// short circuit potentially expensive split op
 			if strings.Contains(opt, "=") {
-				kv := strings.Split(opt, "=")
+				kv := strings.SplitN(opt, "=", 2)
 				options[kv[0]] = kv[1]
 				continue
 			}
@@ -341,6 +356,28 @@ func parseOptions(tag string) map[string]string {
 	return options
 }
 
+// splitTag splits the tag string by commas that are not within parentheses.
+func splitTag(tag string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(tag); i++ {
+		switch tag[i] {
+		case '(':
+			depth++
+		case ')':
+			if depth > 0 {
+				depth--
+			}
+		case ',':
+			if depth == 0 {
+				parts = append(parts, tag[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, tag[start:])
+}
+
 // getMapping returns a mapping for the t type, using the tagName, mapFunc and
 // tagMapFunc to determine the canonical names of fields.
 func getMapping(t reflect.Type, tagName string, mapFunc, tagMapFunc mapf) *StructMap {
@@ -393,12 +430,7 @@ queueLoop:
 				Options: parseOptions(tag),
 			}
 
-			// If the path is empty this path is just the name
-			if tq.parentPath == "" {
-				fi.Path = fi.Name
-			} else {
-				fi.Path = tq.parentPath + "." + fi.Name
-			}
+			fi.Path = tq.pathPrefix + fi.Name
 
 			// Skip unexported fields
 			if len(f.PkgPath) != 0 && !f.Anonymous {
@@ -407,9 +439,12 @@ queueLoop:
 
 			// Do BFS search of anonymous embedded structs
 			if f.Anonymous {
-				pp := tq.parentPath
+				pp := tq.pathPrefix
 				if tag != "" {
-					pp = fi.Path
+					pp = childPathPrefix(fi.Path)
+				}
+				if prefix, ok := fi.Options["prefix"]; ok {
+					pp = tq.pathPrefix + prefix
 				}
 
 				fi.Embedded = true
@@ -424,17 +459,24 @@ queueLoop:
 					typeQueue{
 						t:          Deref(f.Type),
 						fi:         &fi,
-						parentPath: pp,
+						pathPrefix: pp,
 					},
 				)
 			} else if fi.Zero.Kind() == reflect.Struct || (fi.Zero.Kind() == reflect.Ptr && fi.Zero.Type().Elem().Kind() == reflect.Struct) {
+				// Fields of nested structs are addressed as "parent.child", or
+				// "<prefix>child" with the "prefix" option.
+				pp := childPathPrefix(fi.Path)
+				if prefix, ok := fi.Options["prefix"]; ok {
+					pp = tq.pathPrefix + prefix
+				}
+
 				fi.Index = copyAndAppend(tq.fi.Index, fieldPos)
 				fi.Children = make([]*FieldInfo, Deref(f.Type).NumField())
 				queue = append(queue,
 					typeQueue{
 						t:          Deref(f.Type),
 						fi:         &fi,
-						parentPath: fi.Path,
+						pathPrefix: pp,
 					},
 				)
 			}
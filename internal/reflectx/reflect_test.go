@@ -856,3 +856,90 @@ func TestMustBe(t *testing.T) {
 	typ = reflect.TypeOf("string")
 	mustBe(typ, reflect.Struct)
 }
+
+func TestParseOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		tag  string
+		want map[string]string
+	}{
+		{
+			name: "no options",
+			tag:  "name",
+			want: map[string]string{},
+		},
+		{
+			name: "flags and values",
+			tag:  "name,required,size=64",
+			want: map[string]string{"required": "", "size": "64"},
+		},
+		{
+			name: "commas within parentheses",
+			tag:  "price,type=numeric(10,2),notnull",
+			want: map[string]string{"type": "numeric(10,2)", "notnull": ""},
+		},
+		{
+			name: "equal signs in value",
+			tag:  "name,default=a=b",
+			want: map[string]string{"default": "a=b"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, parseOptions(test.tag))
+		})
+	}
+}
+
+func TestPrefixOption(t *testing.T) {
+	type (
+		Publisher struct {
+			Name string `db:"name"`
+		}
+		Author struct {
+			Name      string     `db:"name"`
+			Publisher *Publisher `db:"publisher,prefix=publisher_"`
+		}
+		Book struct {
+			Title  string `db:"title"`
+			Author Author `db:"author,prefix=author_"`
+			Editor Author `db:"editor"`
+		}
+	)
+
+	m := NewMapper("db")
+	fields := m.TypeMap(reflect.TypeOf(Book{}))
+
+	for _, path := range []string{
+		"title",
+		"author",
+		"author_name",
+		"author_publisher",
+		"author_publisher_name",
+		"editor.name",
+		"editor.publisher_name",
+	} {
+		assert.NotNil(t, fields.GetByPath(path), path)
+	}
+	assert.Nil(t, fields.GetByPath("author.name"))
+}
+
+func TestOptionsOnlyTag(t *testing.T) {
+	type User struct {
+		Name  string `db:",required"`
+		Email string `db:"email,required"`
+	}
+
+	m := NewMapper("db")
+	fields := m.TypeMap(reflect.TypeOf(User{}))
+
+	fi := fields.GetByPath("Name")
+	require.NotNil(t, fi)
+	_, ok := fi.Options["required"]
+	assert.True(t, ok, "required option")
+
+	fi = fields.GetByPath("email")
+	require.NotNil(t, fi)
+	_, ok = fi.Options["required"]
+	assert.True(t, ok, "required option")
+}
@@ -0,0 +1,209 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pkg/errors"
+
+	"unknwon.dev/norm"
+	"unknwon.dev/norm/internal/exql"
+	"unknwon.dev/norm/internal/immutable"
+)
+
+var _ norm.TableAlterer = (*tableAlterer)(nil)
+
+type tableAlterer struct {
+	builder *sqlBuilder
+
+	prev *tableAlterer
+	fn   func(*tableAltererQuery) error
+}
+
+func (ta *tableAlterer) frame(fn func(*tableAltererQuery) error) *tableAlterer {
+	return &tableAlterer{
+		prev: ta,
+		fn:   fn,
+	}
+}
+
+func (ta *tableAlterer) Builder() *sqlBuilder {
+	if ta.prev == nil {
+		return ta.builder
+	}
+	return ta.prev.Builder()
+}
+
+func (ta *tableAlterer) Table(table string) *tableAlterer {
+	return ta.frame(func(aq *tableAltererQuery) error {
+		aq.table = table
+		return nil
+	})
+}
+
+func (ta *tableAlterer) IfExists() norm.TableAlterer {
+	return ta.frame(func(aq *tableAltererQuery) error {
+		aq.ifExists = true
+		return nil
+	})
+}
+
+func (ta *tableAlterer) AddColumn(def norm.ColumnDefinition) norm.TableAlterer {
+	return ta.frame(func(aq *tableAltererQuery) error {
+		cd, err := columnDefinition(def)
+		if err != nil {
+			return errors.Wrapf(err, "AddColumn: column %q", def.Name)
+		}
+		aq.alterations = append(aq.alterations, exql.AddColumn(cd))
+		return nil
+	})
+}
+
+func (ta *tableAlterer) DropColumn(name string) norm.TableAlterer {
+	return ta.frame(func(aq *tableAltererQuery) error {
+		aq.alterations = append(aq.alterations, exql.DropColumn(name))
+		return nil
+	})
+}
+
+func (ta *tableAlterer) RenameColumn(name, newName string) norm.TableAlterer {
+	return ta.frame(func(aq *tableAltererQuery) error {
+		aq.alterations = append(aq.alterations, exql.RenameColumn(name, newName))
+		return nil
+	})
+}
+
+func (ta *tableAlterer) AlterColumnType(name, typ string) norm.TableAlterer {
+	return ta.frame(func(aq *tableAltererQuery) error {
+		aq.alterations = append(aq.alterations, exql.AlterColumnType(name, typ))
+		return nil
+	})
+}
+
+func (ta *tableAlterer) SetNotNull(name string) norm.TableAlterer {
+	return ta.frame(func(aq *tableAltererQuery) error {
+		aq.alterations = append(aq.alterations, exql.SetNotNull(name))
+		return nil
+	})
+}
+
+func (ta *tableAlterer) DropNotNull(name string) norm.TableAlterer {
+	return ta.frame(func(aq *tableAltererQuery) error {
+		aq.alterations = append(aq.alterations, exql.DropNotNull(name))
+		return nil
+	})
+}
+
+func (ta *tableAlterer) AddConstraint(name, definition string) norm.TableAlterer {
+	return ta.frame(func(aq *tableAltererQuery) error {
+		aq.alterations = append(aq.alterations, exql.AddConstraint(exql.Constraint(name, definition)))
+		return nil
+	})
+}
+
+func (ta *tableAlterer) DropConstraint(name string) norm.TableAlterer {
+	return ta.frame(func(aq *tableAltererQuery) error {
+		aq.alterations = append(aq.alterations, exql.DropConstraint(name))
+		return nil
+	})
+}
+
+func (ta *tableAlterer) Amend(fn func(query string) string) norm.TableAlterer {
+	return ta.frame(func(aq *tableAltererQuery) error {
+		aq.amendFn = fn
+		return nil
+	})
+}
+
+func (ta *tableAlterer) Exec(ctx context.Context) (sql.Result, error) {
+	aq, err := ta.build()
+	if err != nil {
+		return nil, errors.Wrap(err, "build query")
+	}
+
+	result, err := ta.Builder().withContext(ctx).Executor().Exec(ctx, aq.statement())
+	if err != nil {
+		return nil, errors.Wrap(err, "execute query")
+	}
+	return result, nil
+}
+
+func (ta *tableAlterer) String() string {
+	q, err := ta.Compile()
+	if err != nil {
+		panic("unable to compile ALTER TABLE query: " + err.Error())
+	}
+	return ta.Builder().FormatSQL(q)
+}
+
+func (ta *tableAlterer) build() (*tableAltererQuery, error) {
+	aq, err := immutable.FastForward(ta)
+	if err != nil {
+		return nil, errors.Wrap(err, "construct *tableAltererQuery")
+	}
+
+	q := aq.(*tableAltererQuery)
+	if len(q.alterations) == 0 {
+		return nil, errors.New("no alteration is specified")
+	}
+	if len(q.alterations) > 1 {
+		for _, a := range q.alterations {
+			if a.Type == exql.AlterationRenameColumn {
+				return nil, errors.New("RenameColumn: cannot be combined with other alterations")
+			}
+		}
+	}
+	return q, nil
+}
+
+func (ta *tableAlterer) Compile() (string, error) {
+	aq, err := ta.build()
+	if err != nil {
+		return "", errors.Wrap(err, "build")
+	}
+	return aq.statement().Compile(ta.Builder().Template)
+}
+
+var _ immutable.Immutable = (*tableAlterer)(nil)
+
+func (ta *tableAlterer) Prev() immutable.Immutable {
+	if ta == nil {
+		return nil
+	}
+	return ta.prev
+}
+
+func (ta *tableAlterer) Fn(in interface{}) error {
+	if ta.fn == nil {
+		return nil
+	}
+	return ta.fn(in.(*tableAltererQuery))
+}
+
+func (ta *tableAlterer) Base() interface{} {
+	return &tableAltererQuery{}
+}
+
+type tableAltererQuery struct {
+	table    string
+	ifExists bool
+
+	alterations []*exql.AlterationFragment
+
+	amendFn func(string) string
+}
+
+func (aq *tableAltererQuery) statement() *exql.Statement {
+	stmt := &exql.Statement{
+		Type:        exql.StatementAlterTable,
+		Table:       exql.Table(aq.table),
+		Alterations: exql.Alterations(aq.alterations...),
+		IfExists:    aq.ifExists,
+	}
+	stmt.SetAmend(aq.amendFn)
+	return stmt
+}
@@ -0,0 +1,126 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	mockrequire "github.com/derision-test/go-mockgen/testutil/require"
+	"github.com/stretchr/testify/assert"
+
+	"unknwon.dev/norm"
+	"unknwon.dev/norm/internal/exql"
+)
+
+func TestTableAlterer(t *testing.T) {
+	adapter := NewMockAdapter()
+	adapter.FormatSQLFunc.SetDefaultHook(func(sql string) string {
+		return exql.StripWhitespace(sql)
+	})
+
+	tmpl := defaultTemplate(t)
+	sql := New(adapter, tmpl)
+	tests := []struct {
+		name      string
+		alterer   norm.TableAlterer
+		wantQuery string
+	}{
+		{
+			name: "add column",
+			alterer: sql.
+				AlterTable("users").
+				AddColumn(norm.ColumnDefinition{Name: "age", Type: "INTEGER", NotNull: true, Default: 0}),
+			wantQuery: `ALTER TABLE "users" ADD COLUMN "age" INTEGER NOT NULL DEFAULT '0'`,
+		},
+		{
+			name: "if exists",
+			alterer: sql.
+				AlterTable("users").
+				IfExists().
+				DropColumn("age"),
+			wantQuery: `ALTER TABLE IF EXISTS "users" DROP COLUMN "age"`,
+		},
+		{
+			name: "rename column",
+			alterer: sql.
+				AlterTable("users").
+				RenameColumn("age", "years"),
+			wantQuery: `ALTER TABLE "users" RENAME COLUMN "age" TO "years"`,
+		},
+		{
+			name: "alter columns",
+			alterer: sql.
+				AlterTable("users").
+				AlterColumnType("age", "BIGINT").
+				SetNotNull("name").
+				DropNotNull("nickname"),
+			wantQuery: `ALTER TABLE "users" ALTER COLUMN "age" TYPE BIGINT, ALTER COLUMN "name" SET NOT NULL, ALTER COLUMN "nickname" DROP NOT NULL`,
+		},
+		{
+			name: "constraints",
+			alterer: sql.
+				AlterTable("users").
+				AddConstraint("users_email_key", "UNIQUE (email)").
+				DropConstraint("users_name_key"),
+			wantQuery: `ALTER TABLE "users" ADD CONSTRAINT "users_email_key" UNIQUE (email), DROP CONSTRAINT "users_name_key"`,
+		},
+		{
+			name: "multiple",
+			alterer: sql.
+				AlterTable("users").
+				DropColumn("nickname").
+				AddColumn(norm.ColumnDefinition{Name: "birthday", Type: "DATE"}),
+			wantQuery: `ALTER TABLE "users" DROP COLUMN "nickname", ADD COLUMN "birthday" DATE`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.wantQuery, test.alterer.String())
+		})
+	}
+}
+
+func TestTableAlterer_Errors(t *testing.T) {
+	ctx := context.Background()
+	adapter := NewMockAdapter()
+	tmpl := defaultTemplate(t)
+	sql := New(adapter, tmpl)
+
+	t.Run("no alteration", func(t *testing.T) {
+		_, err := sql.AlterTable("users").Exec(ctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("rename with others", func(t *testing.T) {
+		_, err := sql.AlterTable("users").RenameColumn("age", "years").DropColumn("nickname").Exec(ctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("bad column definition", func(t *testing.T) {
+		_, err := sql.AlterTable("users").AddColumn(norm.ColumnDefinition{Name: "age"}).Exec(ctx)
+		assert.Error(t, err)
+	})
+}
+
+func TestTableAlterer_Exec(t *testing.T) {
+	ctx := context.Background()
+
+	executor := NewMockExecutor()
+	executor.ExecFunc.SetDefaultHook(func(_ context.Context, stmt *exql.Statement, args ...interface{}) (sql.Result, error) {
+		assert.Equal(t, exql.StatementAlterTable, stmt.Type)
+		assert.Empty(t, args)
+		return nil, nil
+	})
+
+	adapter := NewMockAdapter()
+	adapter.ExecutorFunc.SetDefaultReturn(executor)
+
+	tmpl := defaultTemplate(t)
+	_, err := New(adapter, tmpl).AlterTable("users").DropColumn("age").Exec(ctx)
+	assert.NoError(t, err)
+	mockrequire.Called(t, executor.ExecFunc)
+}
@@ -6,22 +6,92 @@
 package sqlbuilder
 
 import (
+	"context"
+	"time"
+
 	"unknwon.dev/norm"
 	"unknwon.dev/norm/adapter"
+	"unknwon.dev/norm/internal/cache"
 	"unknwon.dev/norm/internal/exql"
+	"unknwon.dev/norm/internal/reflectx"
 )
 
+// Options contains options for the SQL query builder.
+type Options struct {
+	// StrictMapping indicates whether to return an error when any column of query
+	// results has no matching field in the destination struct.
+	StrictMapping bool
+	// Mapper is the mapper of struct fields to columns, see NewMapper. Default
+	// is to use the "db" tag and the Go field names.
+	Mapper *reflectx.Mapper
+	// NowFunc is the function to return the current time for automatic
+	// timestamps. Default is norm.Now.
+	NowFunc norm.NowFunc
+	// DB is the database handle that is passed to model lifecycle hooks, see
+	// norm.BeforeInserter for details.
+	DB norm.DB
+	// SoftDeleteTables is the list of tables that are soft-deletable, i.e. rows
+	// are marked as deleted by setting the "deleted_at" column instead of being
+	// deleted. Queries on these tables only apply to rows that are not deleted
+	// unless WithDeleted or OnlyDeleted is used.
+	SoftDeleteTables []string
+	// Scopes is the list of default conditions on columns of tables, whose values
+	// are resolved from the context when executing queries.
+	Scopes []norm.Scope
+	// ScanPlans is the cache of plans to scan rows into structs, see
+	// NewScanPlanCache. Default is a new cache for the builder.
+	ScanPlans *cache.LRU
+}
+
+// NewMapper returns a new mapper of struct fields to columns with given
+// options. The mapper caches the mapping of every struct type, thus it should be
+// shared by query builders of the same database.
+func NewMapper(opts norm.MappingOptions) *reflectx.Mapper {
+	tagName := opts.TagName
+	if tagName == "" {
+		tagName = "db"
+	}
+	return reflectx.NewMapperFunc(tagName, opts.NameFunc)
+}
+
 type sqlBuilder struct {
 	adapter.Adapter
 	*exql.Template
+
+	options Options
 }
 
-// New returns a new SQL query builder with given adapter and template.
-func New(adapter adapter.Adapter, t *exql.Template) norm.SQL {
+// New returns a new SQL query builder with given adapter, template and
+// options.
+func New(adapter adapter.Adapter, t *exql.Template, opts ...Options) norm.SQL {
+	var opt Options
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.ScanPlans == nil {
+		opt.ScanPlans = NewScanPlanCache()
+	}
 	return &sqlBuilder{
 		Adapter:  adapter,
 		Template: t,
+		options:  opt,
+	}
+}
+
+// mapper returns the mapper of struct fields to columns.
+func (b *sqlBuilder) mapper() *reflectx.Mapper {
+	if b.options.Mapper == nil {
+		return defaultMapper
 	}
+	return b.options.Mapper
+}
+
+// now returns the current time for automatic timestamps.
+func (b *sqlBuilder) now() time.Time {
+	if b.options.NowFunc == nil {
+		return norm.Now()
+	}
+	return b.options.NowFunc()
 }
 
 func (b *sqlBuilder) Select(columns ...interface{}) norm.Selector {
@@ -58,3 +128,102 @@ func (b *sqlBuilder) DeleteFrom(table string) norm.Deleter {
 	}
 	return del.Table(table)
 }
+
+func (b *sqlBuilder) CreateTable(table string) norm.TableCreator {
+	tc := &tableCreator{
+		builder: b,
+	}
+	return tc.Table(table)
+}
+
+func (b *sqlBuilder) AlterTable(table string) norm.TableAlterer {
+	ta := &tableAlterer{
+		builder: b,
+	}
+	return ta.Table(table)
+}
+
+func (b *sqlBuilder) DropTable(tables ...string) norm.TableDropper {
+	td := &tableDropper{
+		builder: b,
+	}
+	return td.Tables(tables...)
+}
+
+func (b *sqlBuilder) DropDatabase(database string) norm.DatabaseDropper {
+	dd := &databaseDropper{
+		builder: b,
+	}
+	return dd.Database(database)
+}
+
+func (b *sqlBuilder) Truncate(tables ...string) norm.Truncater {
+	tr := &truncater{
+		builder: b,
+	}
+	return tr.Tables(tables...)
+}
+
+func (b *sqlBuilder) CreateIndex(name string) norm.IndexCreator {
+	ic := &indexCreator{
+		builder: b,
+	}
+	return ic.Name(name)
+}
+
+func (b *sqlBuilder) DropIndex(name string) norm.IndexDropper {
+	id := &indexDropper{
+		builder: b,
+	}
+	return id.Name(name)
+}
+
+func (b *sqlBuilder) CreateView(name string) norm.ViewCreator {
+	vc := &viewCreator{
+		builder: b,
+	}
+	return vc.Name(name)
+}
+
+func (b *sqlBuilder) CreateMaterializedView(name string) norm.ViewCreator {
+	vc := &viewCreator{
+		builder: b,
+	}
+	return vc.Name(name).Materialized()
+}
+
+func (b *sqlBuilder) RefreshMaterializedView(name string) norm.MaterializedViewRefresher {
+	vr := &materializedViewRefresher{
+		builder: b,
+	}
+	return vr.Name(name)
+}
+
+// inTransaction returns true if the executor of the adapter executes queries
+// within a transaction.
+func (b *sqlBuilder) inTransaction() bool {
+	tx, ok := b.Executor().(adapter.Transactional)
+	return ok && tx.InTransaction()
+}
+
+// withContext returns the builder to execute queries with the context, which
+// executes queries within the transaction carried by the context (see
+// norm.WithTx) when the transaction is started from the database handle of the
+// builder, unless the builder is already within a transaction.
+func (b *sqlBuilder) withContext(ctx context.Context) *sqlBuilder {
+	if b.options.DB == nil || b.inTransaction() {
+		return b
+	}
+	tx, ok := norm.TxFromContext(ctx, b.options.DB)
+	if !ok {
+		return b
+	}
+
+	opts := b.options
+	opts.DB = tx
+	return &sqlBuilder{
+		Adapter:  tx.Adapter(),
+		Template: b.Template,
+		options:  opts,
+	}
+}
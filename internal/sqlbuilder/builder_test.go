@@ -0,0 +1,75 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"unknwon.dev/norm"
+	"unknwon.dev/norm/adapter"
+	"unknwon.dev/norm/internal/exql"
+)
+
+// contextTx is a transaction that only implements the Adapter method.
+type contextTx struct {
+	norm.DB
+	adapter adapter.Adapter
+}
+
+func (tx *contextTx) Adapter() adapter.Adapter {
+	return tx.adapter
+}
+
+func TestSQLBuilder_WithContext(t *testing.T) {
+	newAdapter := func(execs *[]string) *MockAdapter {
+		executor := NewMockExecutor()
+		executor.ExecFunc.SetDefaultHook(func(_ context.Context, stmt *exql.Statement, _ ...interface{}) (sql.Result, error) {
+			q, err := stmt.Compile(defaultTemplate(t))
+			require.NoError(t, err)
+			*execs = append(*execs, exql.StripWhitespace(q))
+			return nil, nil
+		})
+
+		adapter := NewMockAdapter()
+		adapter.ExecutorFunc.SetDefaultReturn(executor)
+		return adapter
+	}
+
+	var dbExecs, txExecs, otherExecs []string
+	db := &contextTx{}
+	sqlb := New(newAdapter(&dbExecs), defaultTemplate(t), Options{DB: db})
+	tx := &contextTx{adapter: newAdapter(&txExecs)}
+
+	_, err := sqlb.DeleteFrom("users").Exec(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{`DELETE FROM "users"`}, dbExecs)
+	assert.Empty(t, txExecs)
+
+	ctx := norm.WithTx(context.Background(), db, tx)
+	_, err = sqlb.Truncate("emails").Exec(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{`DELETE FROM "users"`}, dbExecs)
+	assert.Equal(t, []string{`TRUNCATE TABLE "emails"`}, txExecs)
+
+	got, ok := norm.TxFromContext(ctx, db)
+	assert.True(t, ok)
+	assert.Equal(t, tx, got)
+
+	t.Run("builder of another database handle", func(t *testing.T) {
+		other := &contextTx{}
+		_, err := New(newAdapter(&otherExecs), defaultTemplate(t), Options{DB: other}).Truncate("orgs").Exec(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{`TRUNCATE TABLE "orgs"`}, otherExecs)
+		assert.Equal(t, []string{`TRUNCATE TABLE "emails"`}, txExecs)
+
+		_, ok := norm.TxFromContext(ctx, other)
+		assert.False(t, ok)
+	})
+}
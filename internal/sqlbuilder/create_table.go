@@ -0,0 +1,252 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"unknwon.dev/norm"
+	"unknwon.dev/norm/expr"
+	"unknwon.dev/norm/internal/exql"
+	"unknwon.dev/norm/internal/immutable"
+)
+
+var _ norm.TableCreator = (*tableCreator)(nil)
+
+type tableCreator struct {
+	builder *sqlBuilder
+
+	prev *tableCreator
+	fn   func(*tableCreatorQuery) error
+}
+
+func (tc *tableCreator) frame(fn func(*tableCreatorQuery) error) *tableCreator {
+	return &tableCreator{
+		prev: tc,
+		fn:   fn,
+	}
+}
+
+func (tc *tableCreator) Builder() *sqlBuilder {
+	if tc.prev == nil {
+		return tc.builder
+	}
+	return tc.prev.Builder()
+}
+
+func (tc *tableCreator) Table(table string) *tableCreator {
+	return tc.frame(func(tq *tableCreatorQuery) error {
+		tq.table = table
+		return nil
+	})
+}
+
+func (tc *tableCreator) IfNotExists() norm.TableCreator {
+	return tc.frame(func(tq *tableCreatorQuery) error {
+		tq.ifNotExists = true
+		return nil
+	})
+}
+
+func (tc *tableCreator) Columns(defs ...norm.ColumnDefinition) norm.TableCreator {
+	if len(defs) == 0 {
+		return tc
+	}
+	return tc.frame(func(tq *tableCreatorQuery) error {
+		for _, def := range defs {
+			cd, err := columnDefinition(def)
+			if err != nil {
+				return errors.Wrapf(err, "Columns: column %q", def.Name)
+			}
+			tq.definitions = append(tq.definitions, cd)
+		}
+		return nil
+	})
+}
+
+func (tc *tableCreator) Constraint(name, definition string) norm.TableCreator {
+	return tc.frame(func(tq *tableCreatorQuery) error {
+		tq.constraints = append(tq.constraints, exql.Constraint(name, definition))
+		return nil
+	})
+}
+
+func (tc *tableCreator) PrimaryKey(name string, columns ...string) norm.TableCreator {
+	return tc.frame(func(tq *tableCreatorQuery) error {
+		if len(columns) == 0 {
+			return errors.New("PrimaryKey: no column is specified")
+		}
+
+		cs := make([]*exql.ColumnFragment, len(columns))
+		for i := range columns {
+			cs[i] = exql.Column(columns[i])
+		}
+		compiled, err := exql.Columns(cs...).Compile(tc.Builder().Template)
+		if err != nil {
+			return errors.Wrap(err, "PrimaryKey: compile columns")
+		}
+		tq.constraints = append(tq.constraints, exql.Constraint(name, "PRIMARY KEY ("+compiled+")"))
+		return nil
+	})
+}
+
+func (tc *tableCreator) Amend(fn func(query string) string) norm.TableCreator {
+	return tc.frame(func(tq *tableCreatorQuery) error {
+		tq.amendFn = fn
+		return nil
+	})
+}
+
+func (tc *tableCreator) Exec(ctx context.Context) (sql.Result, error) {
+	tq, err := tc.build()
+	if err != nil {
+		return nil, errors.Wrap(err, "build query")
+	}
+
+	result, err := tc.Builder().withContext(ctx).Executor().Exec(ctx, tq.statement())
+	if err != nil {
+		return nil, errors.Wrap(err, "execute query")
+	}
+	return result, nil
+}
+
+func (tc *tableCreator) String() string {
+	q, err := tc.Compile()
+	if err != nil {
+		panic("unable to compile CREATE TABLE query: " + err.Error())
+	}
+	return tc.Builder().FormatSQL(q)
+}
+
+func (tc *tableCreator) build() (*tableCreatorQuery, error) {
+	tq, err := immutable.FastForward(tc)
+	if err != nil {
+		return nil, errors.Wrap(err, "construct *tableCreatorQuery")
+	}
+	return tq.(*tableCreatorQuery), nil
+}
+
+func (tc *tableCreator) Compile() (string, error) {
+	tq, err := tc.build()
+	if err != nil {
+		return "", errors.Wrap(err, "build")
+	}
+	return tq.statement().Compile(tc.Builder().Template)
+}
+
+var _ immutable.Immutable = (*tableCreator)(nil)
+
+func (tc *tableCreator) Prev() immutable.Immutable {
+	if tc == nil {
+		return nil
+	}
+	return tc.prev
+}
+
+func (tc *tableCreator) Fn(in interface{}) error {
+	if tc.fn == nil {
+		return nil
+	}
+	return tc.fn(in.(*tableCreatorQuery))
+}
+
+func (tc *tableCreator) Base() interface{} {
+	return &tableCreatorQuery{}
+}
+
+type tableCreatorQuery struct {
+	table       string
+	ifNotExists bool
+
+	definitions []exql.Fragment
+	constraints []exql.Fragment
+
+	amendFn func(string) string
+}
+
+func (tq *tableCreatorQuery) statement() *exql.Statement {
+	defs := make([]exql.Fragment, 0, len(tq.definitions)+len(tq.constraints))
+	defs = append(defs, tq.definitions...)
+	defs = append(defs, tq.constraints...)
+
+	stmt := &exql.Statement{
+		Type:        exql.StatementCreateTable,
+		Table:       exql.Table(tq.table),
+		Definitions: exql.Definitions(defs...),
+		IfNotExists: tq.ifNotExists,
+	}
+	stmt.SetAmend(tq.amendFn)
+	return stmt
+}
+
+// columnDefinition converts the norm.ColumnDefinition to the
+// *exql.ColumnDefinitionFragment.
+func columnDefinition(def norm.ColumnDefinition) (*exql.ColumnDefinitionFragment, error) {
+	if def.Name == "" {
+		return nil, errors.New("empty column name")
+	} else if def.Type == "" {
+		return nil, errors.New("empty column type")
+	}
+
+	cd := exql.ColumnDefinition(def.Name, def.Type, def.Constraints...)
+	cd.NotNull = def.NotNull
+	if def.Default != nil {
+		value, err := literalValue(def.Default)
+		if err != nil {
+			return nil, errors.Wrap(err, "default value")
+		}
+		cd.Default = value
+	}
+	return cd, nil
+}
+
+// literalValue derives the fragment that represents the given value without
+// any placeholder. This is necessary because data definition statements do not
+// accept bind parameters.
+func literalValue(v interface{}) (exql.Fragment, error) {
+	switch v := v.(type) {
+	case *expr.RawExpr:
+		if len(v.Arguments()) > 0 {
+			return nil, errors.New("bind parameters are not supported in *expr.RawExpr")
+		}
+		return exql.Raw(v.Raw()), nil
+
+	case *expr.FuncExpr:
+		fnName, fnArgs, err := expandFuncExpr(v)
+		if err != nil {
+			return nil, errors.Wrap(err, "expand *expr.FuncExpr")
+		} else if len(fnArgs) > 0 {
+			return nil, errors.New("bind parameters are not supported in *expr.FuncExpr")
+		}
+		return exql.Raw(fnName), nil
+
+	case fmt.Stringer:
+		return quotedValue(v.String()), nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return quotedValue(rv.String()), nil
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return exql.Value(fmt.Sprintf("%v", v)), nil
+	}
+	return nil, errors.Errorf("unsupported type %T", v)
+}
+
+// quotedValue returns the fragment that represents the given string as a
+// quoted literal, with any single quote escaped.
+func quotedValue(s string) exql.Fragment {
+	return exql.Value(strings.ReplaceAll(s, "'", "''"))
+}
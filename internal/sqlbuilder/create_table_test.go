@@ -0,0 +1,183 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	mockrequire "github.com/derision-test/go-mockgen/testutil/require"
+	"github.com/stretchr/testify/assert"
+
+	"unknwon.dev/norm"
+	"unknwon.dev/norm/expr"
+	"unknwon.dev/norm/internal/exql"
+)
+
+type testRole string
+
+type testStatus int
+
+func (s testStatus) String() string {
+	return "it's active"
+}
+
+func TestTableCreator(t *testing.T) {
+	adapter := NewMockAdapter()
+	adapter.FormatSQLFunc.SetDefaultHook(func(sql string) string {
+		return exql.StripWhitespace(sql)
+	})
+
+	tmpl := defaultTemplate(t)
+	sql := New(adapter, tmpl)
+	tests := []struct {
+		name      string
+		creator   norm.TableCreator
+		wantQuery string
+	}{
+		{
+			name: "normal",
+			creator: sql.
+				CreateTable("users").
+				Columns(
+					norm.ColumnDefinition{Name: "id", Type: "BIGSERIAL", Constraints: []string{"PRIMARY KEY"}},
+					norm.ColumnDefinition{Name: "email", Type: "TEXT", NotNull: true},
+				),
+			wantQuery: `CREATE TABLE "users" ("id" BIGSERIAL PRIMARY KEY, "email" TEXT NOT NULL)`,
+		},
+		{
+			name: "if not exists",
+			creator: sql.
+				CreateTable("users").
+				IfNotExists().
+				Columns(norm.ColumnDefinition{Name: "id", Type: "BIGINT"}),
+			wantQuery: `CREATE TABLE IF NOT EXISTS "users" ("id" BIGINT)`,
+		},
+		{
+			name: "defaults",
+			creator: sql.
+				CreateTable("users").
+				Columns(
+					norm.ColumnDefinition{Name: "name", Type: "TEXT", Default: "O'Brien"},
+					norm.ColumnDefinition{Name: "age", Type: "INTEGER", Default: 18},
+					norm.ColumnDefinition{Name: "created_at", Type: "TIMESTAMPTZ", Default: expr.Func("NOW")},
+					norm.ColumnDefinition{Name: "updated_at", Type: "TIMESTAMPTZ", Default: expr.Raw("CURRENT_TIMESTAMP")},
+				),
+			wantQuery: `CREATE TABLE "users" ("name" TEXT DEFAULT 'O''Brien', "age" INTEGER DEFAULT '18', "created_at" TIMESTAMPTZ DEFAULT NOW(), "updated_at" TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP)`,
+		},
+		{
+			name: "named string and stringer defaults",
+			creator: sql.
+				CreateTable("users").
+				Columns(
+					norm.ColumnDefinition{Name: "role", Type: "TEXT", Default: testRole("admin'); DROP TABLE users; --")},
+					norm.ColumnDefinition{Name: "status", Type: "TEXT", Default: testStatus(1)},
+					norm.ColumnDefinition{Name: "active", Type: "BOOLEAN", Default: true},
+				),
+			wantQuery: `CREATE TABLE "users" ("role" TEXT DEFAULT 'admin''); DROP TABLE users; --', "status" TEXT DEFAULT 'it''s active', "active" BOOLEAN DEFAULT 'true')`,
+		},
+		{
+			name: "constraints",
+			creator: sql.
+				CreateTable("users").
+				Columns(norm.ColumnDefinition{Name: "id", Type: "BIGINT"}).
+				Constraint("", "PRIMARY KEY (id)").
+				Columns(norm.ColumnDefinition{Name: "email", Type: "TEXT"}).
+				Constraint("users_email_key", "UNIQUE (email)"),
+			wantQuery: `CREATE TABLE "users" ("id" BIGINT, "email" TEXT, PRIMARY KEY (id), CONSTRAINT "users_email_key" UNIQUE (email))`,
+		},
+		{
+			name: "primary key",
+			creator: sql.
+				CreateTable("members").
+				Columns(
+					norm.ColumnDefinition{Name: "org_id", Type: "BIGINT"},
+					norm.ColumnDefinition{Name: `user\id`, Type: "BIGINT"},
+				).
+				PrimaryKey("members_pkey", "org_id", `user\id`),
+			wantQuery: `CREATE TABLE "members" ("org_id" BIGINT, "user\id" BIGINT, CONSTRAINT "members_pkey" PRIMARY KEY ("org_id", "user\id"))`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.wantQuery, test.creator.String())
+		})
+	}
+}
+
+func TestTableCreator_Errors(t *testing.T) {
+	adapter := NewMockAdapter()
+	tmpl := defaultTemplate(t)
+	sql := New(adapter, tmpl)
+
+	tests := []struct {
+		name string
+		def  norm.ColumnDefinition
+	}{
+		{
+			name: "empty name",
+			def:  norm.ColumnDefinition{Type: "TEXT"},
+		},
+		{
+			name: "empty type",
+			def:  norm.ColumnDefinition{Name: "name"},
+		},
+		{
+			name: "bind parameters",
+			def:  norm.ColumnDefinition{Name: "name", Type: "TEXT", Default: expr.Raw("?", "alice")},
+		},
+		{
+			name: "unsupported default",
+			def:  norm.ColumnDefinition{Name: "tags", Type: "TEXT[]", Default: []string{"a"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := sql.CreateTable("users").Columns(test.def).Exec(context.Background())
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestTableCreator_Amend(t *testing.T) {
+	adapter := NewMockAdapter()
+	adapter.FormatSQLFunc.SetDefaultHook(func(sql string) string {
+		return exql.StripWhitespace(sql)
+	})
+
+	tmpl := defaultTemplate(t)
+	got := New(adapter, tmpl).
+		CreateTable("users").
+		Columns(norm.ColumnDefinition{Name: "id", Type: "BIGINT"}).
+		Amend(func(query string) string {
+			return query + " PARTITION BY RANGE (id)"
+		}).
+		String()
+	want := `CREATE TABLE "users" ("id" BIGINT) PARTITION BY RANGE (id)`
+	assert.Equal(t, want, got)
+}
+
+func TestTableCreator_Exec(t *testing.T) {
+	ctx := context.Background()
+
+	executor := NewMockExecutor()
+	executor.ExecFunc.SetDefaultHook(func(_ context.Context, stmt *exql.Statement, args ...interface{}) (sql.Result, error) {
+		assert.Equal(t, exql.StatementCreateTable, stmt.Type)
+		assert.Empty(t, args)
+		return nil, nil
+	})
+
+	adapter := NewMockAdapter()
+	adapter.ExecutorFunc.SetDefaultReturn(executor)
+
+	tmpl := defaultTemplate(t)
+	_, err := New(adapter, tmpl).
+		CreateTable("users").
+		Columns(norm.ColumnDefinition{Name: "id", Type: "BIGINT"}).
+		Exec(ctx)
+	assert.NoError(t, err)
+	mockrequire.Called(t, executor.ExecFunc)
+}
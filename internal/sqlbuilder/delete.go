@@ -7,6 +7,7 @@ package sqlbuilder
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/pkg/errors"
 
@@ -85,18 +86,68 @@ func (del *deleter) Amend(fn func(string) string) norm.Deleter {
 	})
 }
 
+func (del *deleter) WithDeleted() norm.Deleter {
+	return del.frame(func(dq *deleterQuery) error {
+		dq.deletedScope = scopeWithDeleted
+		return nil
+	})
+}
+
+func (del *deleter) OnlyDeleted() norm.Deleter {
+	return del.frame(func(dq *deleterQuery) error {
+		dq.deletedScope = scopeOnlyDeleted
+		return nil
+	})
+}
+
+func (del *deleter) HardDelete() norm.Deleter {
+	return del.frame(func(dq *deleterQuery) error {
+		dq.hardDelete = true
+		return nil
+	})
+}
+
+func (del *deleter) Exec(ctx context.Context) (sql.Result, error) {
+	b := del.Builder().withContext(ctx)
+	dq, err := del.build()
+	if err != nil {
+		return nil, errors.Wrap(err, "build query")
+	}
+
+	args, err := b.resolveArguments(ctx, dq.arguments())
+	if err != nil {
+		return nil, errors.Wrap(err, "resolve arguments")
+	}
+
+	result, err := b.Executor().Exec(ctx, dq.statement(), args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "execute query")
+	}
+	return result, nil
+}
+
 func (del *deleter) Iterate(ctx context.Context) norm.Iterator {
-	iq, err := del.build()
+	b := del.Builder().withContext(ctx)
+	dq, err := del.build()
 	if err != nil {
 		return &iterator{err: errors.Wrap(err, "build query")}
 	}
 
-	adapter := del.Builder().Adapter
-	rows, err := adapter.Executor().Query(ctx, iq.statement(), del.Arguments()...) //nolint:rowserrcheck
+	args, err := b.resolveArguments(ctx, dq.arguments())
+	if err != nil {
+		return &iterator{err: errors.Wrap(err, "resolve arguments")}
+	}
+
+	adapter := b.Adapter
+	rows, err := adapter.Executor().Query(ctx, dq.statement(), args...) //nolint:rowserrcheck
 	return &iterator{
-		adapter: adapter,
-		cursor:  rows,
-		err:     errors.Wrap(err, "execute query"),
+		adapter:   adapter,
+		cursor:    rows,
+		mapper:    b.mapper(),
+		strict:    b.options.StrictMapping,
+		scanPlans: b.options.ScanPlans,
+		db:        b.options.DB,
+		err:       errors.Wrap(err, "execute query"),
 	}
 }
 
@@ -117,11 +168,34 @@ func (del *deleter) String() string {
 }
 
 func (del *deleter) build() (*deleterQuery, error) {
-	dq, err := immutable.FastForward(del)
+	q, err := immutable.FastForward(del)
 	if err != nil {
 		return nil, errors.Wrap(err, "construct *deleterQuery")
 	}
-	return dq.(*deleterQuery), nil
+
+	// The conditions of scopes and soft-deleted rows are appended last so that
+	// they are not replaced by Where.
+	dq := q.(*deleterQuery)
+	table := tableName(dq.table)
+	conds := del.Builder().scopeConditions(table, "")
+	softDeletable := del.Builder().softDeletable(table)
+	if softDeletable {
+		if cond := dq.deletedScope.condition(softDeleteColumn); cond != nil {
+			conds = append(conds, cond)
+		}
+	}
+	if len(conds) > 0 {
+		dq.where = groupWhere(dq.where)
+		if err = dq.and(del.Builder().Template, conds...); err != nil {
+			return nil, errors.Wrap(err, "append implicit conditions")
+		}
+	}
+	if softDeletable && !dq.hardDelete {
+		cv := exql.ColumnValue(softDeleteColumn, del.Builder().Layout(exql.LayoutAssignmentOperator), exql.Raw("?"))
+		dq.softDelete = exql.ColumnValues(cv)
+		dq.softDeleteArgs = []interface{}{del.Builder().now()}
+	}
+	return dq, nil
 }
 
 func (del *deleter) Arguments() []interface{} {
@@ -175,11 +249,18 @@ type deleterQuery struct {
 
 	returning *exql.ReturningFragment
 
+	deletedScope deletedScope
+	hardDelete   bool
+	// softDelete is the SET clause to mark rows as deleted, it is only set when
+	// the table is soft-deletable and HardDelete is not used.
+	softDelete     *exql.ColumnValuesFragment
+	softDeleteArgs []interface{}
+
 	amendFn func(string) string
 }
 
 func (dq *deleterQuery) arguments() []interface{} {
-	return flattenArguments(dq.whereArgs)
+	return flattenArguments(dq.softDeleteArgs, dq.whereArgs)
 }
 
 func (dq *deleterQuery) and(t *exql.Template, conditions ...interface{}) error {
@@ -203,6 +284,10 @@ func (dq *deleterQuery) statement() *exql.Statement {
 		Where:     dq.where,
 		Returning: dq.returning,
 	}
+	if dq.softDelete != nil {
+		stmt.Type = exql.StatementUpdate
+		stmt.ColumnValues = dq.softDelete
+	}
 	stmt.SetAmend(dq.amendFn)
 	return stmt
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"unknwon.dev/norm"
+	"unknwon.dev/norm/adapter"
 	"unknwon.dev/norm/expr"
 	"unknwon.dev/norm/internal/exql"
 )
@@ -109,8 +110,12 @@ func TestDeleter_Iterate(t *testing.T) {
 		return nil
 	})
 
+	var gotArgs []interface{}
 	executor := NewMockExecutor()
-	executor.QueryFunc.SetDefaultReturn(cursor, nil)
+	executor.QueryFunc.SetDefaultHook(func(_ context.Context, _ *exql.Statement, args ...interface{}) (adapter.Rows, error) {
+		gotArgs = args
+		return cursor, nil
+	})
 
 	typer := NewMockTyper()
 	typer.ValuerFunc.SetDefaultHook(func(v interface{}) interface{} {
@@ -128,10 +133,35 @@ func TestDeleter_Iterate(t *testing.T) {
 	sqlb := New(adapter, tmpl)
 
 	dest := make([]map[string]interface{}, 0)
-	err := sqlb.DeleteFrom("users").All(ctx, &dest)
+	err := sqlb.DeleteFrom("users").Where("id = ?", 1).All(ctx, &dest)
 	assert.NoError(t, err)
 	mockrequire.Called(t, cursor.ScanFunc)
 
+	// Arguments are converted by the executor, not the builder.
+	assert.Equal(t, []interface{}{1}, gotArgs)
+	mockrequire.NotCalled(t, typer.ValuerFunc)
+
 	err = sqlb.DeleteFrom("users").One(ctx, &dest)
 	assert.EqualError(t, err, sql.ErrNoRows.Error())
 }
+
+func TestDeleter_Exec(t *testing.T) {
+	ctx := context.Background()
+
+	executor := NewMockExecutor()
+	executor.ExecFunc.SetDefaultHook(func(_ context.Context, stmt *exql.Statement, args ...interface{}) (sql.Result, error) {
+		got, err := stmt.Compile(defaultTemplate(t))
+		assert.NoError(t, err)
+		assert.Equal(t, `DELETE FROM "users" WHERE id = ?`, exql.StripWhitespace(got))
+		assert.Equal(t, []interface{}{1}, args)
+		return nil, nil
+	})
+
+	adapter := NewMockAdapter()
+	adapter.ExecutorFunc.SetDefaultReturn(executor)
+
+	tmpl := defaultTemplate(t)
+	_, err := New(adapter, tmpl).DeleteFrom("users").Where("id = ?", 1).Exec(ctx)
+	assert.NoError(t, err)
+	mockrequire.Called(t, executor.ExecFunc)
+}
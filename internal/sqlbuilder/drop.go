@@ -0,0 +1,275 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pkg/errors"
+
+	"unknwon.dev/norm"
+	"unknwon.dev/norm/internal/exql"
+	"unknwon.dev/norm/internal/immutable"
+)
+
+var _ norm.TableDropper = (*tableDropper)(nil)
+
+type tableDropper struct {
+	builder *sqlBuilder
+
+	prev *tableDropper
+	fn   func(*tableDropperQuery) error
+}
+
+func (td *tableDropper) frame(fn func(*tableDropperQuery) error) *tableDropper {
+	return &tableDropper{
+		prev: td,
+		fn:   fn,
+	}
+}
+
+func (td *tableDropper) Builder() *sqlBuilder {
+	if td.prev == nil {
+		return td.builder
+	}
+	return td.prev.Builder()
+}
+
+func (td *tableDropper) Tables(tables ...string) *tableDropper {
+	return td.frame(func(dq *tableDropperQuery) error {
+		dq.tables = tables
+		return nil
+	})
+}
+
+func (td *tableDropper) IfExists() norm.TableDropper {
+	return td.frame(func(dq *tableDropperQuery) error {
+		dq.ifExists = true
+		return nil
+	})
+}
+
+func (td *tableDropper) Cascade() norm.TableDropper {
+	return td.frame(func(dq *tableDropperQuery) error {
+		dq.cascade = true
+		return nil
+	})
+}
+
+func (td *tableDropper) Amend(fn func(query string) string) norm.TableDropper {
+	return td.frame(func(dq *tableDropperQuery) error {
+		dq.amendFn = fn
+		return nil
+	})
+}
+
+func (td *tableDropper) Exec(ctx context.Context) (sql.Result, error) {
+	dq, err := td.build()
+	if err != nil {
+		return nil, errors.Wrap(err, "build query")
+	}
+
+	result, err := td.Builder().withContext(ctx).Executor().Exec(ctx, dq.statement())
+	if err != nil {
+		return nil, errors.Wrap(err, "execute query")
+	}
+	return result, nil
+}
+
+func (td *tableDropper) String() string {
+	q, err := td.Compile()
+	if err != nil {
+		panic("unable to compile DROP TABLE query: " + err.Error())
+	}
+	return td.Builder().FormatSQL(q)
+}
+
+func (td *tableDropper) build() (*tableDropperQuery, error) {
+	dq, err := immutable.FastForward(td)
+	if err != nil {
+		return nil, errors.Wrap(err, "construct *tableDropperQuery")
+	}
+
+	q := dq.(*tableDropperQuery)
+	if len(q.tables) == 0 {
+		return nil, errors.New("no table is specified")
+	}
+	return q, nil
+}
+
+func (td *tableDropper) Compile() (string, error) {
+	dq, err := td.build()
+	if err != nil {
+		return "", errors.Wrap(err, "build")
+	}
+	return dq.statement().Compile(td.Builder().Template)
+}
+
+var _ immutable.Immutable = (*tableDropper)(nil)
+
+func (td *tableDropper) Prev() immutable.Immutable {
+	if td == nil {
+		return nil
+	}
+	return td.prev
+}
+
+func (td *tableDropper) Fn(in interface{}) error {
+	if td.fn == nil {
+		return nil
+	}
+	return td.fn(in.(*tableDropperQuery))
+}
+
+func (td *tableDropper) Base() interface{} {
+	return &tableDropperQuery{}
+}
+
+type tableDropperQuery struct {
+	tables   []string
+	ifExists bool
+	cascade  bool
+
+	amendFn func(string) string
+}
+
+// tablesFragment constructs a *exql.TablesFragment with the given table names.
+func tablesFragment(tables []string) *exql.TablesFragment {
+	ts := make([]*exql.TableFragment, len(tables))
+	for i := range tables {
+		ts[i] = exql.Table(tables[i])
+	}
+	return exql.Tables(ts...)
+}
+
+func (dq *tableDropperQuery) statement() *exql.Statement {
+	stmt := &exql.Statement{
+		Type:     exql.StatementDropTable,
+		Table:    tablesFragment(dq.tables),
+		IfExists: dq.ifExists,
+		Cascade:  dq.cascade,
+	}
+	stmt.SetAmend(dq.amendFn)
+	return stmt
+}
+
+var _ norm.DatabaseDropper = (*databaseDropper)(nil)
+
+type databaseDropper struct {
+	builder *sqlBuilder
+
+	prev *databaseDropper
+	fn   func(*databaseDropperQuery) error
+}
+
+func (dd *databaseDropper) frame(fn func(*databaseDropperQuery) error) *databaseDropper {
+	return &databaseDropper{
+		prev: dd,
+		fn:   fn,
+	}
+}
+
+func (dd *databaseDropper) Builder() *sqlBuilder {
+	if dd.prev == nil {
+		return dd.builder
+	}
+	return dd.prev.Builder()
+}
+
+func (dd *databaseDropper) Database(database string) *databaseDropper {
+	return dd.frame(func(dq *databaseDropperQuery) error {
+		dq.database = database
+		return nil
+	})
+}
+
+func (dd *databaseDropper) IfExists() norm.DatabaseDropper {
+	return dd.frame(func(dq *databaseDropperQuery) error {
+		dq.ifExists = true
+		return nil
+	})
+}
+
+func (dd *databaseDropper) Amend(fn func(query string) string) norm.DatabaseDropper {
+	return dd.frame(func(dq *databaseDropperQuery) error {
+		dq.amendFn = fn
+		return nil
+	})
+}
+
+func (dd *databaseDropper) Exec(ctx context.Context) (sql.Result, error) {
+	dq, err := dd.build()
+	if err != nil {
+		return nil, errors.Wrap(err, "build query")
+	}
+
+	result, err := dd.Builder().withContext(ctx).Executor().Exec(ctx, dq.statement())
+	if err != nil {
+		return nil, errors.Wrap(err, "execute query")
+	}
+	return result, nil
+}
+
+func (dd *databaseDropper) String() string {
+	q, err := dd.Compile()
+	if err != nil {
+		panic("unable to compile DROP DATABASE query: " + err.Error())
+	}
+	return dd.Builder().FormatSQL(q)
+}
+
+func (dd *databaseDropper) build() (*databaseDropperQuery, error) {
+	dq, err := immutable.FastForward(dd)
+	if err != nil {
+		return nil, errors.Wrap(err, "construct *databaseDropperQuery")
+	}
+	return dq.(*databaseDropperQuery), nil
+}
+
+func (dd *databaseDropper) Compile() (string, error) {
+	dq, err := dd.build()
+	if err != nil {
+		return "", errors.Wrap(err, "build")
+	}
+	return dq.statement().Compile(dd.Builder().Template)
+}
+
+var _ immutable.Immutable = (*databaseDropper)(nil)
+
+func (dd *databaseDropper) Prev() immutable.Immutable {
+	if dd == nil {
+		return nil
+	}
+	return dd.prev
+}
+
+func (dd *databaseDropper) Fn(in interface{}) error {
+	if dd.fn == nil {
+		return nil
+	}
+	return dd.fn(in.(*databaseDropperQuery))
+}
+
+func (dd *databaseDropper) Base() interface{} {
+	return &databaseDropperQuery{}
+}
+
+type databaseDropperQuery struct {
+	database string
+	ifExists bool
+
+	amendFn func(string) string
+}
+
+func (dq *databaseDropperQuery) statement() *exql.Statement {
+	stmt := &exql.Statement{
+		Type:     exql.StatementDropDatabase,
+		Database: exql.Database(dq.database),
+		IfExists: dq.ifExists,
+	}
+	stmt.SetAmend(dq.amendFn)
+	return stmt
+}
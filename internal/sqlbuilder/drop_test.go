@@ -0,0 +1,97 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	mockrequire "github.com/derision-test/go-mockgen/testutil/require"
+	"github.com/stretchr/testify/assert"
+
+	"unknwon.dev/norm/internal/exql"
+)
+
+func TestTableDropper(t *testing.T) {
+	adapter := NewMockAdapter()
+	adapter.FormatSQLFunc.SetDefaultHook(func(sql string) string {
+		return exql.StripWhitespace(sql)
+	})
+
+	tmpl := defaultTemplate(t)
+	sql := New(adapter, tmpl)
+
+	t.Run("normal", func(t *testing.T) {
+		got := sql.DropTable("users").String()
+		assert.Equal(t, `DROP TABLE "users"`, got)
+	})
+
+	t.Run("if exists cascade", func(t *testing.T) {
+		got := sql.DropTable("users", "emails").IfExists().Cascade().String()
+		assert.Equal(t, `DROP TABLE IF EXISTS "users", "emails" CASCADE`, got)
+	})
+
+	t.Run("no table", func(t *testing.T) {
+		_, err := sql.DropTable().Exec(context.Background())
+		assert.Error(t, err)
+	})
+}
+
+func TestTableDropper_Exec(t *testing.T) {
+	ctx := context.Background()
+
+	executor := NewMockExecutor()
+	executor.ExecFunc.SetDefaultHook(func(_ context.Context, stmt *exql.Statement, _ ...interface{}) (sql.Result, error) {
+		assert.Equal(t, exql.StatementDropTable, stmt.Type)
+		return nil, nil
+	})
+
+	adapter := NewMockAdapter()
+	adapter.ExecutorFunc.SetDefaultReturn(executor)
+
+	tmpl := defaultTemplate(t)
+	_, err := New(adapter, tmpl).DropTable("users").Exec(ctx)
+	assert.NoError(t, err)
+	mockrequire.Called(t, executor.ExecFunc)
+}
+
+func TestDatabaseDropper(t *testing.T) {
+	adapter := NewMockAdapter()
+	adapter.FormatSQLFunc.SetDefaultHook(func(sql string) string {
+		return exql.StripWhitespace(sql)
+	})
+
+	tmpl := defaultTemplate(t)
+	sql := New(adapter, tmpl)
+
+	t.Run("normal", func(t *testing.T) {
+		got := sql.DropDatabase("norm").String()
+		assert.Equal(t, `DROP DATABASE "norm"`, got)
+	})
+
+	t.Run("if exists", func(t *testing.T) {
+		got := sql.DropDatabase("norm").IfExists().String()
+		assert.Equal(t, `DROP DATABASE IF EXISTS "norm"`, got)
+	})
+}
+
+func TestDatabaseDropper_Exec(t *testing.T) {
+	ctx := context.Background()
+
+	executor := NewMockExecutor()
+	executor.ExecFunc.SetDefaultHook(func(_ context.Context, stmt *exql.Statement, _ ...interface{}) (sql.Result, error) {
+		assert.Equal(t, exql.StatementDropDatabase, stmt.Type)
+		return nil, nil
+	})
+
+	adapter := NewMockAdapter()
+	adapter.ExecutorFunc.SetDefaultReturn(executor)
+
+	tmpl := defaultTemplate(t)
+	_, err := New(adapter, tmpl).DropDatabase("norm").Exec(ctx)
+	assert.NoError(t, err)
+	mockrequire.Called(t, executor.ExecFunc)
+}
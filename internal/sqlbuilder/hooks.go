@@ -0,0 +1,77 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"unknwon.dev/norm"
+)
+
+// validate calls the Validate of the model.
+func validate(model interface{}) error {
+	if v, ok := model.(norm.Validator); ok {
+		return errors.Wrap(v.Validate(), "Validate")
+	}
+	return nil
+}
+
+// beforeInsert calls the BeforeInsert hook and then the Validate of every
+// model.
+func beforeInsert(ctx context.Context, db norm.DB, models []interface{}) error {
+	for _, m := range models {
+		if hook, ok := m.(norm.BeforeInserter); ok {
+			if err := hook.BeforeInsert(ctx, db); err != nil {
+				return errors.Wrap(err, "BeforeInsert")
+			}
+		}
+		if err := validate(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// afterInsert calls the AfterInsert hook of every model.
+func afterInsert(ctx context.Context, db norm.DB, models []interface{}) error {
+	for _, m := range models {
+		if hook, ok := m.(norm.AfterInserter); ok {
+			if err := hook.AfterInsert(ctx, db); err != nil {
+				return errors.Wrap(err, "AfterInsert")
+			}
+		}
+	}
+	return nil
+}
+
+// beforeUpdate calls the BeforeUpdate hook and then the Validate of every
+// model.
+func beforeUpdate(ctx context.Context, db norm.DB, models []interface{}) error {
+	for _, m := range models {
+		if hook, ok := m.(norm.BeforeUpdater); ok {
+			if err := hook.BeforeUpdate(ctx, db); err != nil {
+				return errors.Wrap(err, "BeforeUpdate")
+			}
+		}
+		if err := validate(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// afterUpdate calls the AfterUpdate hook of every model.
+func afterUpdate(ctx context.Context, db norm.DB, models []interface{}) error {
+	for _, m := range models {
+		if hook, ok := m.(norm.AfterUpdater); ok {
+			if err := hook.AfterUpdate(ctx, db); err != nil {
+				return errors.Wrap(err, "AfterUpdate")
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,275 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	mockrequire "github.com/derision-test/go-mockgen/testutil/require"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"unknwon.dev/norm"
+	"unknwon.dev/norm/adapter"
+	"unknwon.dev/norm/internal/exql"
+)
+
+// hookedUser is a model that implements all lifecycle hooks and records the
+// calls.
+type hookedUser struct {
+	Name  string   `db:"name"`
+	calls []string `db:"-"`
+}
+
+func (u *hookedUser) BeforeInsert(context.Context, norm.DB) error {
+	u.calls = append(u.calls, "BeforeInsert")
+	u.Name = strings.TrimSpace(u.Name)
+	return nil
+}
+
+func (u *hookedUser) AfterInsert(context.Context, norm.DB) error {
+	u.calls = append(u.calls, "AfterInsert")
+	return nil
+}
+
+func (u *hookedUser) BeforeUpdate(context.Context, norm.DB) error {
+	u.calls = append(u.calls, "BeforeUpdate")
+	u.Name = strings.ToUpper(u.Name)
+	return nil
+}
+
+func (u *hookedUser) AfterUpdate(context.Context, norm.DB) error {
+	u.calls = append(u.calls, "AfterUpdate")
+	return nil
+}
+
+func (u *hookedUser) AfterFind(context.Context, norm.DB) error {
+	u.calls = append(u.calls, "AfterFind")
+	if u.Name == "" {
+		return errors.New("empty name")
+	}
+	return nil
+}
+
+func (u *hookedUser) Validate() error {
+	u.calls = append(u.calls, "Validate")
+	if u.Name == "" {
+		return errors.New("empty name")
+	}
+	return nil
+}
+
+// dbUser is a model that records the database handles passed to the after
+// hooks.
+type dbUser struct {
+	Name string    `db:"name"`
+	dbs  []norm.DB `db:"-"`
+}
+
+func (u *dbUser) AfterInsert(_ context.Context, db norm.DB) error {
+	u.dbs = append(u.dbs, db)
+	return nil
+}
+
+func (u *dbUser) AfterUpdate(_ context.Context, db norm.DB) error {
+	u.dbs = append(u.dbs, db)
+	return nil
+}
+
+func TestHooks(t *testing.T) {
+	ctx := context.Background()
+
+	newAdapter := func(executor *MockExecutor) *MockAdapter {
+		typer := NewMockTyper()
+		typer.ScannerFunc.SetDefaultHook(func(v interface{}) interface{} {
+			return v
+		})
+		typer.ValuerFunc.SetDefaultHook(func(v interface{}) interface{} {
+			return v
+		})
+
+		adp := NewMockAdapter()
+		adp.ExecutorFunc.SetDefaultReturn(executor)
+		adp.TyperFunc.SetDefaultReturn(typer)
+		return adp
+	}
+
+	t.Run("insert", func(t *testing.T) {
+		executor := NewMockExecutor()
+		executor.ExecFunc.SetDefaultHook(func(_ context.Context, _ *exql.Statement, args ...interface{}) (sql.Result, error) {
+			assert.Equal(t, []interface{}{"alice"}, args)
+			return nil, nil
+		})
+
+		user := &hookedUser{Name: "  alice "}
+		_, err := New(newAdapter(executor), defaultTemplate(t)).InsertInto("users").Values(user).Exec(ctx)
+		require.NoError(t, err)
+		mockrequire.Called(t, executor.ExecFunc)
+		assert.Equal(t, []string{"BeforeInsert", "Validate", "AfterInsert"}, user.calls)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		cursor := NewMockCursor()
+		cursor.ColumnsFunc.SetDefaultReturn([]string{"name"}, nil)
+		cursor.NextFunc.PushReturn(true)
+		cursor.ScanFunc.PushHook(func(dest ...interface{}) error {
+			*dest[0].(*string) = "ALICE"
+			return nil
+		})
+
+		executor := NewMockExecutor()
+		executor.QueryFunc.SetDefaultHook(func(_ context.Context, _ *exql.Statement, args ...interface{}) (adapter.Rows, error) {
+			assert.Equal(t, []interface{}{"ALICE", 1}, args)
+			return cursor, nil
+		})
+
+		user := &hookedUser{Name: "alice"}
+		var got hookedUser
+		err := New(newAdapter(executor), defaultTemplate(t)).
+			Update("users").
+			Set(user).
+			Where("id = ?", 1).
+			Returning("name").
+			One(ctx, &got)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"BeforeUpdate", "Validate", "AfterUpdate"}, user.calls)
+		assert.Equal(t, hookedUser{Name: "ALICE", calls: []string{"AfterFind"}}, got)
+	})
+
+	t.Run("iterate", func(t *testing.T) {
+		newCursor := func() *MockCursor {
+			cursor := NewMockCursor()
+			cursor.ColumnsFunc.SetDefaultReturn([]string{"name"}, nil)
+			cursor.NextFunc.PushReturn(true)
+			cursor.ScanFunc.PushHook(func(dest ...interface{}) error {
+				*dest[0].(*string) = "alice"
+				return nil
+			})
+			return cursor
+		}
+
+		t.Run("called after results are mapped", func(t *testing.T) {
+			executor := NewMockExecutor()
+			executor.QueryFunc.SetDefaultReturn(newCursor(), nil)
+
+			user := &hookedUser{Name: "alice"}
+			iter := New(newAdapter(executor), defaultTemplate(t)).
+				InsertInto("users").
+				Values(user).
+				Returning("name").
+				Iterate(ctx)
+			assert.Equal(t, []string{"BeforeInsert", "Validate"}, user.calls)
+
+			var got []*hookedUser
+			err := iter.All(ctx, &got)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"BeforeInsert", "Validate", "AfterInsert"}, user.calls)
+
+			require.NoError(t, iter.Close())
+			assert.Equal(t, []string{"BeforeInsert", "Validate", "AfterInsert"}, user.calls)
+		})
+
+		t.Run("called after closed", func(t *testing.T) {
+			executor := NewMockExecutor()
+			executor.QueryFunc.SetDefaultReturn(newCursor(), nil)
+
+			user := &hookedUser{Name: "alice"}
+			iter := New(newAdapter(executor), defaultTemplate(t)).
+				Update("users").
+				Set(user).
+				Returning("name").
+				Iterate(ctx)
+			assert.Equal(t, []string{"BeforeUpdate", "Validate"}, user.calls)
+
+			require.NoError(t, iter.Close())
+			assert.Equal(t, []string{"BeforeUpdate", "Validate", "AfterUpdate"}, user.calls)
+		})
+
+		t.Run("not called on errors", func(t *testing.T) {
+			cursor := NewMockCursor()
+			cursor.ColumnsFunc.SetDefaultReturn([]string{"name"}, nil)
+			cursor.NextFunc.PushReturn(true)
+
+			executor := NewMockExecutor()
+			executor.QueryFunc.SetDefaultReturn(cursor, nil)
+
+			user := &hookedUser{Name: "alice"}
+			var got []*hookedUser
+			err := New(newAdapter(executor), defaultTemplate(t)).
+				InsertInto("users").
+				Values(user).
+				Returning("name").
+				Iterate(ctx).
+				All(ctx, &got)
+			assert.EqualError(t, err, "AfterFind: empty name")
+			mockrequire.Called(t, cursor.CloseFunc)
+			assert.Equal(t, []string{"BeforeInsert", "Validate"}, user.calls)
+		})
+	})
+
+	t.Run("database handle of transaction context", func(t *testing.T) {
+		newTxAdapter := func() *MockAdapter {
+			executor := NewMockExecutor()
+			executor.QueryFunc.SetDefaultHook(func(context.Context, *exql.Statement, ...interface{}) (adapter.Rows, error) {
+				cursor := NewMockCursor()
+				cursor.ColumnsFunc.SetDefaultReturn([]string{"name"}, nil)
+				cursor.NextFunc.PushReturn(true)
+				return cursor, nil
+			})
+			return newAdapter(executor)
+		}
+
+		rootExecutor := NewMockExecutor()
+		db := &contextTx{}
+		sqlb := New(newAdapter(rootExecutor), defaultTemplate(t), Options{DB: db})
+		tx := &contextTx{adapter: newTxAdapter()}
+		txCtx := norm.WithTx(ctx, db, tx)
+
+		user := &dbUser{Name: "alice"}
+		var got []*dbUser
+		err := sqlb.InsertInto("users").Values(user).Returning("name").All(txCtx, &got)
+		require.NoError(t, err)
+		var one dbUser
+		err = sqlb.InsertInto("users").Values(user).Returning("name").One(txCtx, &one)
+		require.NoError(t, err)
+		err = sqlb.Update("users").Set(user).Returning("name").All(txCtx, &got)
+		require.NoError(t, err)
+		err = sqlb.Update("users").Set(user).Returning("name").One(txCtx, &one)
+		require.NoError(t, err)
+		mockrequire.NotCalled(t, rootExecutor.QueryFunc)
+
+		require.Len(t, user.dbs, 4)
+		for _, got := range user.dbs {
+			assert.Same(t, tx, got)
+		}
+	})
+
+	t.Run("validation error aborts", func(t *testing.T) {
+		executor := NewMockExecutor()
+
+		user := &hookedUser{Name: "  "}
+		_, err := New(newAdapter(executor), defaultTemplate(t)).InsertInto("users").Values(user).Exec(ctx)
+		assert.EqualError(t, err, "Validate: empty name")
+		mockrequire.NotCalled(t, executor.ExecFunc)
+	})
+
+	t.Run("after find error", func(t *testing.T) {
+		cursor := NewMockCursor()
+		cursor.ColumnsFunc.SetDefaultReturn([]string{"name"}, nil)
+		cursor.NextFunc.PushReturn(true)
+
+		executor := NewMockExecutor()
+		executor.QueryFunc.SetDefaultReturn(cursor, nil)
+
+		var got []*hookedUser
+		err := New(newAdapter(executor), defaultTemplate(t)).SelectFrom("users").All(ctx, &got)
+		assert.EqualError(t, err, "AfterFind: empty name")
+		mockrequire.Called(t, cursor.CloseFunc)
+	})
+}
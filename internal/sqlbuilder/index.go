@@ -0,0 +1,366 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pkg/errors"
+
+	"unknwon.dev/norm"
+	"unknwon.dev/norm/expr"
+	"unknwon.dev/norm/internal/exql"
+	"unknwon.dev/norm/internal/immutable"
+)
+
+// errConcurrentlyInTransaction is returned when a query with the CONCURRENTLY
+// clause is about to be executed within a transaction.
+var errConcurrentlyInTransaction = errors.New("CONCURRENTLY cannot be executed within a transaction")
+
+var _ norm.IndexCreator = (*indexCreator)(nil)
+
+type indexCreator struct {
+	builder *sqlBuilder
+
+	prev *indexCreator
+	fn   func(*indexCreatorQuery) error
+}
+
+func (ic *indexCreator) frame(fn func(*indexCreatorQuery) error) *indexCreator {
+	return &indexCreator{
+		prev: ic,
+		fn:   fn,
+	}
+}
+
+func (ic *indexCreator) Builder() *sqlBuilder {
+	if ic.prev == nil {
+		return ic.builder
+	}
+	return ic.prev.Builder()
+}
+
+func (ic *indexCreator) Name(name string) *indexCreator {
+	return ic.frame(func(iq *indexCreatorQuery) error {
+		iq.name = name
+		return nil
+	})
+}
+
+func (ic *indexCreator) On(table string) norm.IndexCreator {
+	return ic.frame(func(iq *indexCreatorQuery) error {
+		iq.table = table
+		return nil
+	})
+}
+
+func (ic *indexCreator) Columns(columns ...interface{}) norm.IndexCreator {
+	if len(columns) == 0 {
+		return ic
+	}
+	return ic.frame(func(iq *indexCreatorQuery) error {
+		for _, column := range columns {
+			switch v := column.(type) {
+			case string:
+				iq.columns = append(iq.columns, exql.Column(v))
+			case *expr.RawExpr:
+				if len(v.Arguments()) > 0 {
+					return errors.New("Columns: bind parameters are not supported in *expr.RawExpr")
+				}
+				iq.columns = append(iq.columns, exql.Column(exql.Raw(v.Raw())))
+			default:
+				return errors.Errorf("Columns: unsupported type %T", v)
+			}
+		}
+		return nil
+	})
+}
+
+func (ic *indexCreator) Unique() norm.IndexCreator {
+	return ic.frame(func(iq *indexCreatorQuery) error {
+		iq.unique = true
+		return nil
+	})
+}
+
+func (ic *indexCreator) IfNotExists() norm.IndexCreator {
+	return ic.frame(func(iq *indexCreatorQuery) error {
+		iq.ifNotExists = true
+		return nil
+	})
+}
+
+func (ic *indexCreator) Where(conds ...interface{}) norm.IndexCreator {
+	if len(conds) == 0 {
+		return ic
+	}
+	return ic.frame(func(iq *indexCreatorQuery) error {
+		where, args, err := parseConditionExpressions(ic.Builder().Template, conds)
+		if err != nil {
+			return errors.Wrap(err, "Where: parse condition expressions")
+		} else if len(args) > 0 {
+			return errors.New("Where: bind parameters are not supported")
+		}
+		iq.where = exql.Where(where...)
+		return nil
+	})
+}
+
+func (ic *indexCreator) Concurrently() norm.IndexCreator {
+	return ic.frame(func(iq *indexCreatorQuery) error {
+		iq.concurrently = true
+		return nil
+	})
+}
+
+func (ic *indexCreator) Amend(fn func(query string) string) norm.IndexCreator {
+	return ic.frame(func(iq *indexCreatorQuery) error {
+		iq.amendFn = fn
+		return nil
+	})
+}
+
+func (ic *indexCreator) Exec(ctx context.Context) (sql.Result, error) {
+	b := ic.Builder().withContext(ctx)
+	iq, err := ic.build()
+	if err != nil {
+		return nil, errors.Wrap(err, "build query")
+	}
+
+	if iq.concurrently && b.inTransaction() {
+		return nil, errConcurrentlyInTransaction
+	}
+
+	result, err := b.Executor().Exec(ctx, iq.statement())
+	if err != nil {
+		return nil, errors.Wrap(err, "execute query")
+	}
+	return result, nil
+}
+
+func (ic *indexCreator) String() string {
+	q, err := ic.Compile()
+	if err != nil {
+		panic("unable to compile CREATE INDEX query: " + err.Error())
+	}
+	return ic.Builder().FormatSQL(q)
+}
+
+func (ic *indexCreator) build() (*indexCreatorQuery, error) {
+	iq, err := immutable.FastForward(ic)
+	if err != nil {
+		return nil, errors.Wrap(err, "construct *indexCreatorQuery")
+	}
+
+	q := iq.(*indexCreatorQuery)
+	if q.table == "" {
+		return nil, errors.New("no table is specified")
+	} else if len(q.columns) == 0 {
+		return nil, errors.New("no column is specified")
+	}
+	return q, nil
+}
+
+func (ic *indexCreator) Compile() (string, error) {
+	iq, err := ic.build()
+	if err != nil {
+		return "", errors.Wrap(err, "build")
+	}
+	return iq.statement().Compile(ic.Builder().Template)
+}
+
+var _ immutable.Immutable = (*indexCreator)(nil)
+
+func (ic *indexCreator) Prev() immutable.Immutable {
+	if ic == nil {
+		return nil
+	}
+	return ic.prev
+}
+
+func (ic *indexCreator) Fn(in interface{}) error {
+	if ic.fn == nil {
+		return nil
+	}
+	return ic.fn(in.(*indexCreatorQuery))
+}
+
+func (ic *indexCreator) Base() interface{} {
+	return &indexCreatorQuery{}
+}
+
+type indexCreatorQuery struct {
+	name         string
+	table        string
+	columns      []*exql.ColumnFragment
+	where        *exql.WhereFragment
+	unique       bool
+	ifNotExists  bool
+	concurrently bool
+
+	amendFn func(string) string
+}
+
+func (iq *indexCreatorQuery) statement() *exql.Statement {
+	stmt := &exql.Statement{
+		Type:         exql.StatementCreateIndex,
+		Index:        exql.Table(iq.name),
+		Table:        exql.Table(iq.table),
+		Columns:      exql.Columns(iq.columns...),
+		Where:        iq.where,
+		Unique:       iq.unique,
+		IfNotExists:  iq.ifNotExists,
+		Concurrently: iq.concurrently,
+	}
+	stmt.SetAmend(iq.amendFn)
+	return stmt
+}
+
+var _ norm.IndexDropper = (*indexDropper)(nil)
+
+type indexDropper struct {
+	builder *sqlBuilder
+
+	prev *indexDropper
+	fn   func(*indexDropperQuery) error
+}
+
+func (id *indexDropper) frame(fn func(*indexDropperQuery) error) *indexDropper {
+	return &indexDropper{
+		prev: id,
+		fn:   fn,
+	}
+}
+
+func (id *indexDropper) Builder() *sqlBuilder {
+	if id.prev == nil {
+		return id.builder
+	}
+	return id.prev.Builder()
+}
+
+func (id *indexDropper) Name(name string) *indexDropper {
+	return id.frame(func(iq *indexDropperQuery) error {
+		iq.name = name
+		return nil
+	})
+}
+
+func (id *indexDropper) IfExists() norm.IndexDropper {
+	return id.frame(func(iq *indexDropperQuery) error {
+		iq.ifExists = true
+		return nil
+	})
+}
+
+func (id *indexDropper) Cascade() norm.IndexDropper {
+	return id.frame(func(iq *indexDropperQuery) error {
+		iq.cascade = true
+		return nil
+	})
+}
+
+func (id *indexDropper) Concurrently() norm.IndexDropper {
+	return id.frame(func(iq *indexDropperQuery) error {
+		iq.concurrently = true
+		return nil
+	})
+}
+
+func (id *indexDropper) Amend(fn func(query string) string) norm.IndexDropper {
+	return id.frame(func(iq *indexDropperQuery) error {
+		iq.amendFn = fn
+		return nil
+	})
+}
+
+func (id *indexDropper) Exec(ctx context.Context) (sql.Result, error) {
+	b := id.Builder().withContext(ctx)
+	iq, err := id.build()
+	if err != nil {
+		return nil, errors.Wrap(err, "build query")
+	}
+
+	if iq.concurrently && b.inTransaction() {
+		return nil, errConcurrentlyInTransaction
+	}
+
+	result, err := b.Executor().Exec(ctx, iq.statement())
+	if err != nil {
+		return nil, errors.Wrap(err, "execute query")
+	}
+	return result, nil
+}
+
+func (id *indexDropper) String() string {
+	q, err := id.Compile()
+	if err != nil {
+		panic("unable to compile DROP INDEX query: " + err.Error())
+	}
+	return id.Builder().FormatSQL(q)
+}
+
+func (id *indexDropper) build() (*indexDropperQuery, error) {
+	iq, err := immutable.FastForward(id)
+	if err != nil {
+		return nil, errors.Wrap(err, "construct *indexDropperQuery")
+	}
+
+	q := iq.(*indexDropperQuery)
+	if q.concurrently && q.cascade {
+		return nil, errors.New("CONCURRENTLY cannot be combined with CASCADE")
+	}
+	return q, nil
+}
+
+func (id *indexDropper) Compile() (string, error) {
+	iq, err := id.build()
+	if err != nil {
+		return "", errors.Wrap(err, "build")
+	}
+	return iq.statement().Compile(id.Builder().Template)
+}
+
+var _ immutable.Immutable = (*indexDropper)(nil)
+
+func (id *indexDropper) Prev() immutable.Immutable {
+	if id == nil {
+		return nil
+	}
+	return id.prev
+}
+
+func (id *indexDropper) Fn(in interface{}) error {
+	if id.fn == nil {
+		return nil
+	}
+	return id.fn(in.(*indexDropperQuery))
+}
+
+func (id *indexDropper) Base() interface{} {
+	return &indexDropperQuery{}
+}
+
+type indexDropperQuery struct {
+	name         string
+	ifExists     bool
+	cascade      bool
+	concurrently bool
+
+	amendFn func(string) string
+}
+
+func (iq *indexDropperQuery) statement() *exql.Statement {
+	stmt := &exql.Statement{
+		Type:         exql.StatementDropIndex,
+		Index:        exql.Table(iq.name),
+		IfExists:     iq.ifExists,
+		Cascade:      iq.cascade,
+		Concurrently: iq.concurrently,
+	}
+	stmt.SetAmend(iq.amendFn)
+	return stmt
+}
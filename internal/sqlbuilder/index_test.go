@@ -0,0 +1,202 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	mockrequire "github.com/derision-test/go-mockgen/testutil/require"
+	"github.com/stretchr/testify/assert"
+
+	"unknwon.dev/norm"
+	"unknwon.dev/norm/expr"
+	"unknwon.dev/norm/internal/exql"
+)
+
+// mockTxExecutor is a MockExecutor that reports to execute queries within a
+// transaction.
+type mockTxExecutor struct {
+	*MockExecutor
+}
+
+func (*mockTxExecutor) InTransaction() bool {
+	return true
+}
+
+func TestIndexCreator(t *testing.T) {
+	adapter := NewMockAdapter()
+	adapter.FormatSQLFunc.SetDefaultHook(func(sql string) string {
+		return exql.StripWhitespace(sql)
+	})
+
+	tmpl := defaultTemplate(t)
+	sql := New(adapter, tmpl)
+	tests := []struct {
+		name      string
+		creator   norm.IndexCreator
+		wantQuery string
+	}{
+		{
+			name:      "normal",
+			creator:   sql.CreateIndex("users_email_idx").On("users").Columns("email"),
+			wantQuery: `CREATE INDEX "users_email_idx" ON "users" ("email")`,
+		},
+		{
+			name: "unique if not exists",
+			creator: sql.CreateIndex("users_email_idx").
+				On("users").
+				Columns("email").
+				Columns(expr.Raw("lower(name)")).
+				Unique().
+				IfNotExists(),
+			wantQuery: `CREATE UNIQUE INDEX IF NOT EXISTS "users_email_idx" ON "users" ("email", lower(name))`,
+		},
+		{
+			name: "partial concurrently",
+			creator: sql.CreateIndex("users_email_idx").
+				On("users").
+				Columns("email").
+				Where("deleted_at IS NULL").
+				Concurrently(),
+			wantQuery: `CREATE INDEX CONCURRENTLY "users_email_idx" ON "users" ("email") WHERE deleted_at IS NULL`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.wantQuery, test.creator.String())
+		})
+	}
+}
+
+func TestIndexCreator_Errors(t *testing.T) {
+	ctx := context.Background()
+	adapter := NewMockAdapter()
+	tmpl := defaultTemplate(t)
+	sql := New(adapter, tmpl)
+
+	tests := []struct {
+		name    string
+		creator norm.IndexCreator
+	}{
+		{
+			name:    "no table",
+			creator: sql.CreateIndex("users_email_idx").Columns("email"),
+		},
+		{
+			name:    "no column",
+			creator: sql.CreateIndex("users_email_idx").On("users"),
+		},
+		{
+			name:    "bind parameters in columns",
+			creator: sql.CreateIndex("users_email_idx").On("users").Columns(expr.Raw("lower(?)", "name")),
+		},
+		{
+			name:    "bind parameters in where",
+			creator: sql.CreateIndex("users_email_idx").On("users").Columns("email").Where("status = ?", "active"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := test.creator.Exec(ctx)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestIndexCreator_Exec(t *testing.T) {
+	ctx := context.Background()
+	tmpl := defaultTemplate(t)
+
+	t.Run("normal", func(t *testing.T) {
+		executor := NewMockExecutor()
+		executor.ExecFunc.SetDefaultHook(func(_ context.Context, stmt *exql.Statement, _ ...interface{}) (sql.Result, error) {
+			assert.Equal(t, exql.StatementCreateIndex, stmt.Type)
+			return nil, nil
+		})
+
+		adapter := NewMockAdapter()
+		adapter.ExecutorFunc.SetDefaultReturn(executor)
+
+		_, err := New(adapter, tmpl).CreateIndex("users_email_idx").On("users").Columns("email").Concurrently().Exec(ctx)
+		assert.NoError(t, err)
+		mockrequire.Called(t, executor.ExecFunc)
+	})
+
+	t.Run("concurrently in transaction", func(t *testing.T) {
+		executor := NewMockExecutor()
+		adapter := NewMockAdapter()
+		adapter.ExecutorFunc.SetDefaultReturn(&mockTxExecutor{MockExecutor: executor})
+
+		q := New(adapter, tmpl).CreateIndex("users_email_idx").On("users").Columns("email")
+		_, err := q.Exec(ctx)
+		assert.NoError(t, err)
+
+		_, err = q.Concurrently().Exec(ctx)
+		assert.Equal(t, errConcurrentlyInTransaction, err)
+		mockrequire.CalledOnce(t, executor.ExecFunc)
+	})
+}
+
+func TestIndexDropper(t *testing.T) {
+	adapter := NewMockAdapter()
+	adapter.FormatSQLFunc.SetDefaultHook(func(sql string) string {
+		return exql.StripWhitespace(sql)
+	})
+
+	tmpl := defaultTemplate(t)
+	sql := New(adapter, tmpl)
+
+	t.Run("normal", func(t *testing.T) {
+		got := sql.DropIndex("users_email_idx").String()
+		assert.Equal(t, `DROP INDEX "users_email_idx"`, got)
+	})
+
+	t.Run("if exists cascade", func(t *testing.T) {
+		got := sql.DropIndex("users_email_idx").IfExists().Cascade().String()
+		assert.Equal(t, `DROP INDEX IF EXISTS "users_email_idx" CASCADE`, got)
+	})
+
+	t.Run("concurrently", func(t *testing.T) {
+		got := sql.DropIndex("users_email_idx").Concurrently().String()
+		assert.Equal(t, `DROP INDEX CONCURRENTLY "users_email_idx"`, got)
+	})
+
+	t.Run("concurrently with cascade", func(t *testing.T) {
+		_, err := sql.DropIndex("users_email_idx").Concurrently().Cascade().Exec(context.Background())
+		assert.Error(t, err)
+	})
+}
+
+func TestIndexDropper_Exec(t *testing.T) {
+	ctx := context.Background()
+	tmpl := defaultTemplate(t)
+
+	t.Run("normal", func(t *testing.T) {
+		executor := NewMockExecutor()
+		executor.ExecFunc.SetDefaultHook(func(_ context.Context, stmt *exql.Statement, _ ...interface{}) (sql.Result, error) {
+			assert.Equal(t, exql.StatementDropIndex, stmt.Type)
+			return nil, nil
+		})
+
+		adapter := NewMockAdapter()
+		adapter.ExecutorFunc.SetDefaultReturn(executor)
+
+		_, err := New(adapter, tmpl).DropIndex("users_email_idx").Concurrently().Exec(ctx)
+		assert.NoError(t, err)
+		mockrequire.Called(t, executor.ExecFunc)
+	})
+
+	t.Run("concurrently in transaction", func(t *testing.T) {
+		executor := NewMockExecutor()
+		adapter := NewMockAdapter()
+		adapter.ExecutorFunc.SetDefaultReturn(&mockTxExecutor{MockExecutor: executor})
+
+		_, err := New(adapter, tmpl).DropIndex("users_email_idx").Concurrently().Exec(ctx)
+		assert.Equal(t, errConcurrentlyInTransaction, err)
+		mockrequire.NotCalled(t, executor.ExecFunc)
+	})
+}
@@ -7,6 +7,7 @@ package sqlbuilder
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/pkg/errors"
 
@@ -63,6 +64,16 @@ func (ins *inserter) Values(values ...interface{}) norm.Inserter {
 	if len(values) == 0 {
 		return ins
 	}
+	if len(values) == 1 && isStructValue(ins.Builder().Typer(), values[0]) {
+		v := values[0]
+		return ins.frame(func(iq *inserterQuery) error {
+			// Values are taken when building the query, so that changes made by hooks
+			// are included.
+			columns, args := structValues(ins.Builder().mapper(), v)
+			iq.models = append(iq.models, v)
+			return errors.Wrapf(iq.pushStruct(columns, args), "Values: %T", v)
+		})
+	}
 	return ins.frame(func(iq *inserterQuery) error {
 		vs := make([]exql.Fragment, 0, len(values))
 		args := make([]interface{}, 0, len(values))
@@ -77,6 +88,7 @@ func (ins *inserter) Values(values ...interface{}) norm.Inserter {
 		}
 		iq.values = append(iq.values, exql.ValuesGroup(vs...))
 		iq.arguments = append(iq.arguments, args...)
+		iq.valuesArgs = append(iq.valuesArgs, len(args))
 		return nil
 	})
 }
@@ -102,27 +114,107 @@ func (ins *inserter) Amend(fn func(query string) string) norm.Inserter {
 	})
 }
 
-func (ins *inserter) Iterate(ctx context.Context) norm.Iterator {
+// prepare builds the query after setting automatic timestamps and calling the
+// BeforeInsert hooks of models.
+func (ins *inserter) prepare(ctx context.Context) (*inserterQuery, error) {
+	b := ins.Builder().withContext(ctx)
 	iq, err := ins.build()
 	if err != nil {
-		return &iterator{err: errors.Wrap(err, "build query")}
+		return nil, errors.Wrap(err, "build query")
+	}
+	if len(iq.models) == 0 {
+		return iq, nil
+	}
+
+	now := b.now()
+	for _, m := range iq.models {
+		if err = setTimestamps(b.mapper(), m, now, true); err != nil {
+			return nil, errors.Wrapf(err, "set timestamps of %T", m)
+		}
+	}
+	if err = beforeInsert(ctx, b.options.DB, iq.models); err != nil {
+		return nil, err
+	}
+	// Rebuild the query to include changes made by hooks
+	iq, err = ins.build()
+	if err != nil {
+		return nil, errors.Wrap(err, "build query")
+	}
+	return iq, nil
+}
+
+func (ins *inserter) Exec(ctx context.Context) (sql.Result, error) {
+	b := ins.Builder().withContext(ctx)
+	iq, err := ins.prepare(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	args, err := b.resolveArguments(ctx, iq.arguments)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolve arguments")
 	}
 
-	adapter := ins.Builder().Adapter
-	rows, err := adapter.Executor().Query(ctx, iq.statement(), iq.arguments...) //nolint:rowserrcheck
+	result, err := b.Executor().Exec(ctx, iq.statement(), args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "execute query")
+	}
+
+	err = afterInsert(ctx, b.options.DB, iq.models)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// iterate executes the query without calling the AfterInsert hooks.
+func (ins *inserter) iterate(ctx context.Context) (*iterator, []interface{}) {
+	b := ins.Builder().withContext(ctx)
+	iq, err := ins.prepare(ctx)
+	if err != nil {
+		return &iterator{err: err}, nil
+	}
+
+	args, err := b.resolveArguments(ctx, iq.arguments)
+	if err != nil {
+		return &iterator{err: errors.Wrap(err, "resolve arguments")}, nil
+	}
+
+	adapter := b.Adapter
+	rows, err := adapter.Executor().Query(ctx, iq.statement(), args...) //nolint:rowserrcheck
 	return &iterator{
-		adapter: adapter,
-		cursor:  rows,
-		err:     errors.Wrap(err, "execute query"),
+		adapter:   adapter,
+		cursor:    rows,
+		mapper:    b.mapper(),
+		strict:    b.options.StrictMapping,
+		scanPlans: b.options.ScanPlans,
+		db:        b.options.DB,
+		err:       errors.Wrap(err, "execute query"),
+	}, iq.models
+}
+
+func (ins *inserter) Iterate(ctx context.Context) norm.Iterator {
+	iter, models := ins.iterate(ctx)
+	iter.closed = func() error {
+		return afterInsert(ctx, iter.db, models)
 	}
+	return iter
 }
 
 func (ins *inserter) All(ctx context.Context, destSlice interface{}) error {
-	return ins.Iterate(ctx).All(ctx, destSlice)
+	iter, models := ins.iterate(ctx)
+	if err := iter.All(ctx, destSlice); err != nil {
+		return err
+	}
+	return afterInsert(ctx, iter.db, models)
 }
 
 func (ins *inserter) One(ctx context.Context, dest interface{}) error {
-	return ins.Iterate(ctx).One(ctx, dest)
+	iter, models := ins.iterate(ctx)
+	if err := iter.One(ctx, dest); err != nil {
+		return err
+	}
+	return afterInsert(ctx, iter.db, models)
 }
 
 func (ins *inserter) String() string {
@@ -134,11 +226,19 @@ func (ins *inserter) String() string {
 }
 
 func (ins *inserter) build() (*inserterQuery, error) {
-	iq, err := immutable.FastForward(ins)
+	q, err := immutable.FastForward(ins)
 	if err != nil {
 		return nil, errors.Wrap(err, "construct *inserterQuery")
 	}
-	return iq.(*inserterQuery), nil
+
+	iq := q.(*inserterQuery)
+	table := tableName(iq.table)
+	for _, s := range ins.Builder().scopesOf(table) {
+		if err = iq.pushScope(table, s); err != nil {
+			return nil, errors.Wrapf(err, "scope column %q", s.Column)
+		}
+	}
+	return iq, nil
 }
 
 func (ins *inserter) Arguments() []interface{} {
@@ -190,12 +290,86 @@ type inserterQuery struct {
 
 	values    []*exql.ValuesGroupFragment
 	arguments []interface{}
+	// valuesArgs is the number of arguments of each values group.
+	valuesArgs []int
+	// models is the list of structs that are passed to Values.
+	models []interface{}
 
 	returning *exql.ReturningFragment
 
 	amendFn func(string) string
 }
 
+// pushStruct appends values of a struct to the VALUES clause, where columns and
+// values are returned by structValues. The columns are derived from the struct
+// when they are not yet defined, otherwise only values of the defined columns
+// are used.
+func (iq *inserterQuery) pushStruct(columns []string, values []interface{}) error {
+	if iq.columns == nil {
+		cs := make([]*exql.ColumnFragment, len(columns))
+		for i := range columns {
+			cs[i] = exql.Column(columns[i])
+		}
+		iq.columns = exql.Columns(cs...)
+	} else {
+		index := make(map[string]int, len(columns))
+		for i := range columns {
+			index[columns[i]] = i
+		}
+
+		picked := make([]interface{}, len(iq.columns.Columns))
+		for i, c := range iq.columns.Columns {
+			name, ok := c.Name.(string)
+			if !ok {
+				return errors.Errorf("column %v is not a name", c.Name)
+			}
+			j, ok := index[name]
+			if !ok {
+				return errors.Errorf("column %q has no matching field", name)
+			}
+			picked[i] = values[j]
+		}
+		values = picked
+	}
+
+	vs := make([]exql.Fragment, len(values))
+	for i := range vs {
+		vs[i] = exql.Raw("?")
+	}
+	iq.values = append(iq.values, exql.ValuesGroup(vs...))
+	iq.arguments = append(iq.arguments, values...)
+	iq.valuesArgs = append(iq.valuesArgs, len(values))
+	return nil
+}
+
+// pushScope sets the column of the scope to its value in every values group,
+// unless the column is specified.
+func (iq *inserterQuery) pushScope(table string, scope *norm.Scope) error {
+	if len(iq.values) == 0 {
+		return nil
+	} else if iq.columns == nil {
+		return errors.New("columns are not specified")
+	}
+	for _, c := range iq.columns.Columns {
+		if c.Name == scope.Column {
+			return nil
+		}
+	}
+
+	iq.columns.Append(exql.Column(scope.Column))
+	args := make([]interface{}, 0, len(iq.arguments)+len(iq.values))
+	offset := 0
+	for i := range iq.values {
+		iq.values[i].Values = append(iq.values[i].Values, exql.Raw("?"))
+		args = append(args, iq.arguments[offset:offset+iq.valuesArgs[i]]...)
+		args = append(args, &scopeValue{table: table, scope: scope})
+		offset += iq.valuesArgs[i]
+		iq.valuesArgs[i]++
+	}
+	iq.arguments = args
+	return nil
+}
+
 func (iq *inserterQuery) statement() *exql.Statement {
 	stmt := &exql.Statement{
 		Type:      exql.StatementInsert,
@@ -92,6 +92,75 @@ func TestInserter(t *testing.T) {
 	}
 }
 
+func TestInserter_Struct(t *testing.T) {
+	typer := NewMockTyper()
+	typer.ValuerFunc.SetDefaultHook(func(v interface{}) interface{} {
+		return v
+	})
+
+	adapter := NewMockAdapter()
+	adapter.TyperFunc.SetDefaultReturn(typer)
+	adapter.FormatSQLFunc.SetDefaultHook(func(sql string) string {
+		return exql.StripWhitespace(sql)
+	})
+
+	type Profile struct {
+		Bio string
+	}
+	type team struct {
+		Name string
+	}
+	type user struct {
+		ID        int64 `db:"-"`
+		FirstName string
+		LastName  string `db:"surname"`
+		*Profile
+		Team team
+	}
+
+	tmpl := defaultTemplate(t)
+	sql := New(adapter, tmpl, Options{Mapper: NewMapper(norm.MappingOptions{NameFunc: norm.SnakeCase})})
+	tests := []struct {
+		name      string
+		inserter  norm.Inserter
+		wantQuery string
+		wantArgs  []interface{}
+	}{
+		{
+			name: "derived columns",
+			inserter: sql.
+				InsertInto("users").
+				Values(&user{ID: 1, FirstName: "alice", LastName: "john", Profile: &Profile{Bio: "hi"}}).
+				Values(user{FirstName: "bob", LastName: "youth"}),
+			wantQuery: `INSERT INTO "users" ("first_name", "surname", "bio") VALUES (?, ?, ?), (?, ?, ?)`,
+			wantArgs:  []interface{}{"alice", "john", "hi", "bob", "youth", nil},
+		},
+		{
+			name: "defined columns",
+			inserter: sql.
+				InsertInto("users").
+				Columns("surname", "first_name").
+				Values(user{FirstName: "alice", LastName: "john"}),
+			wantQuery: `INSERT INTO "users" ("surname", "first_name") VALUES (?, ?)`,
+			wantArgs:  []interface{}{"john", "alice"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.wantQuery, test.inserter.String())
+			assert.Equal(t, test.wantArgs, test.inserter.Arguments())
+		})
+	}
+
+	t.Run("unknown column", func(t *testing.T) {
+		_, err := sql.InsertInto("users").
+			Columns("age").
+			Values(user{FirstName: "alice"}).(compilable).
+			Compile()
+		assert.EqualError(t, err, `build: construct *inserterQuery: Values: sqlbuilder.user: column "age" has no matching field`)
+	})
+}
+
 func TestInserter_Amend(t *testing.T) {
 	adapter := NewMockAdapter()
 	adapter.FormatSQLFunc.SetDefaultHook(func(sql string) string {
@@ -150,3 +219,24 @@ func TestInserter_Iterate(t *testing.T) {
 	err = sqlb.InsertInto("users").One(ctx, &dest)
 	assert.EqualError(t, err, sql.ErrNoRows.Error())
 }
+
+func TestInserter_Exec(t *testing.T) {
+	ctx := context.Background()
+
+	executor := NewMockExecutor()
+	executor.ExecFunc.SetDefaultHook(func(_ context.Context, stmt *exql.Statement, args ...interface{}) (sql.Result, error) {
+		got, err := stmt.Compile(defaultTemplate(t))
+		assert.NoError(t, err)
+		assert.Equal(t, `INSERT INTO "users" ("name") VALUES (?)`, exql.StripWhitespace(got))
+		assert.Equal(t, []interface{}{"alice"}, args)
+		return nil, nil
+	})
+
+	adapter := NewMockAdapter()
+	adapter.ExecutorFunc.SetDefaultReturn(executor)
+
+	tmpl := defaultTemplate(t)
+	_, err := New(adapter, tmpl).InsertInto("users").Columns("name").Values("alice").Exec(ctx)
+	assert.NoError(t, err)
+	mockrequire.Called(t, executor.ExecFunc)
+}
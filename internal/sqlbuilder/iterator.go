@@ -8,12 +8,16 @@ package sqlbuilder
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"reflect"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
 	"unknwon.dev/norm"
 	"unknwon.dev/norm/adapter"
+	"unknwon.dev/norm/internal/cache"
 	"unknwon.dev/norm/internal/reflectx"
 )
 
@@ -22,13 +26,32 @@ var _ norm.Iterator = (*iterator)(nil)
 type iterator struct {
 	adapter adapter.Adapter
 	cursor  adapter.Rows
-	err     error
+	// mapper is the mapper of struct fields to columns.
+	mapper *reflectx.Mapper
+	// strict indicates whether to use the strict mapping mode.
+	strict bool
+	// scanPlans is the cache of plans to scan rows into structs, plans are not
+	// cached when it is nil.
+	scanPlans *cache.LRU
+	// db is the database handle that is passed to AfterFind hooks.
+	db norm.DB
+	// closed is called once the cursor is closed without any error, e.g. to call
+	// AfterInsert hooks after results of Inserter.Iterate are consumed.
+	closed func() error
+	// multi indicates whether result sets are iterated by NextResultSet, only in
+	// which case the cursor is kept open after mapping a result set.
+	multi bool
+	// advanced indicates whether the cursor has been advanced to the next result
+	// set after the current one is mapped.
+	advanced bool
+	err      error
 }
 
 func newIterator(adapter adapter.Adapter, cursor adapter.Rows) *iterator {
 	return &iterator{
 		adapter: adapter,
 		cursor:  cursor,
+		mapper:  defaultMapper,
 	}
 }
 
@@ -51,31 +74,92 @@ func (iter *iterator) Close() error {
 	if err != nil {
 		return err
 	}
-	return iter.cursor.Err()
+	if err = iter.cursor.Err(); err != nil {
+		return err
+	}
+
+	if iter.err != nil || iter.closed == nil {
+		return nil
+	}
+	return iter.closed()
+}
+
+func (iter *iterator) NextResultSet() bool {
+	if iter.err != nil || iter.cursor == nil {
+		return false
+	}
+	if !iter.multi {
+		// The first call enters the first result set.
+		iter.multi = true
+		return true
+	}
+	if iter.advanced {
+		iter.advanced = false
+		return true
+	}
+
+	if iter.cursor.NextResultSet() {
+		return true
+	}
+	if err := iter.Close(); err != nil {
+		_ = iter.setErr(err)
+	}
+	return false
+}
+
+// mapped advances the cursor to the next result set after the current one is
+// mapped, or closes the cursor when result sets are not iterated by
+// NextResultSet or there is no further result set.
+func (iter *iterator) mapped() {
+	if iter.multi && iter.cursor.NextResultSet() {
+		iter.advanced = true
+		return
+	}
+	if err := iter.Close(); err != nil {
+		_ = iter.setErr(err)
+	}
+}
+
+// checkMappable returns an error if the current result set cannot be mapped.
+func (iter *iterator) checkMappable() error {
+	if err := iter.Err(); err != nil {
+		return err
+	}
+	if iter.cursor == nil {
+		return errors.New("the iterator is closed")
+	}
+	if iter.advanced {
+		return errors.New("the current result set is already mapped, call NextResultSet to advance")
+	}
+	return nil
 }
 
 func (iter *iterator) All(ctx context.Context, dest interface{}) (err error) {
-	if err = iter.Err(); err != nil {
+	if err = iter.checkMappable(); err != nil {
 		return err
 	}
-	defer func() { _ = iter.Close() }()
 
-	if err = fetchRows(ctx, iter.adapter.Typer(), iter.cursor, dest); err != nil {
-		return iter.setErr(err)
+	if err = iter.fetchRows(ctx, dest); err != nil {
+		_ = iter.setErr(err)
+		_ = iter.Close()
+		return err
 	}
-	return nil
+	iter.mapped()
+	return iter.Err()
 }
 
-func (iter *iterator) One(_ context.Context, dest interface{}) (err error) {
-	if err = iter.Err(); err != nil {
+func (iter *iterator) One(ctx context.Context, dest interface{}) (err error) {
+	if err = iter.checkMappable(); err != nil {
 		return err
 	}
-	defer func() { _ = iter.Close() }()
 
-	if err = fetchRow(iter.adapter.Typer(), iter.cursor, dest); err != nil {
-		return iter.setErr(err)
+	if err = iter.fetchRow(ctx, dest); err != nil {
+		_ = iter.setErr(err)
+		_ = iter.Close()
+		return err
 	}
-	return nil
+	iter.mapped()
+	return iter.Err()
 }
 
 func reset(v interface{}) {
@@ -95,64 +179,381 @@ func reset(v interface{}) {
 
 var defaultMapper = reflectx.NewMapper("db")
 
-func scanResult(typer adapter.Typer, rows adapter.Rows, typ reflect.Type, columns []string) (result reflect.Value, err error) {
-	switch typ.Kind() {
-	case reflect.Map:
-		result = reflect.MakeMap(typ)
-	case reflect.Struct:
-		result = reflect.New(typ)
-	default:
-		return reflect.Value{}, errors.New("the type must be a map or struct")
+var scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+
+// structColumns returns the columns with aliases for every field of the
+// destination type, see norm.Selector.ColumnsOf for details.
+func structColumns(mapper *reflectx.Mapper, table string, dest interface{}) ([]interface{}, error) {
+	if dest == nil {
+		return nil, errors.New("the destination cannot be nil")
+	}
+	typ := reflectx.Deref(reflect.TypeOf(dest))
+	if typ.Kind() != reflect.Struct {
+		return nil, errors.Errorf("the destination must be a struct but got %T", dest)
+	}
+
+	typeMap := mapper.TypeMap(typ)
+	var columns []interface{}
+	for _, fi := range typeMap.Index {
+		if fi.Embedded || fi.Name == "" || typeMap.Paths[fi.Path] != fi || !reflectx.IsColumn(fi) {
+			continue
+		}
+
+		qualifier := table
+		for p := fi.Parent; p != nil && p.Field.Type != nil; p = p.Parent {
+			if !p.Embedded {
+				qualifier = p.Name
+				break
+			}
+		}
+
+		column := fi.Name
+		if qualifier != "" {
+			column = qualifier + "." + column
+		}
+		if column != fi.Path {
+			column += " AS " + fi.Path
+		}
+		columns = append(columns, column)
+	}
+
+	if len(columns) == 0 {
+		return nil, errors.Errorf("no column is found in %T", dest)
+	}
+	return columns, nil
+}
+
+// isStructValue returns true if the value is a struct or a pointer to struct
+// that is mapped to columns field by field, rather than a single value.
+func isStructValue(typer adapter.Typer, v interface{}) bool {
+	if v == nil {
+		return false
+	}
+	if _, ok := v.(driver.Valuer); ok {
+		return false
+	}
+
+	typ := reflect.TypeOf(v)
+	if typ.Kind() == reflect.Ptr {
+		if reflect.ValueOf(v).IsNil() {
+			return false
+		}
+		typ = typ.Elem()
+	}
+	return typ.Kind() == reflect.Struct && !isScalar(typer, typ)
+}
+
+// structFields returns fields of the struct type that are mapped to columns as
+// a whole. Fields of nested structs are skipped as they are not columns of the
+// same table (e.g. "author.name"), but fields of embedded structs are included.
+func structFields(mapper *reflectx.Mapper, typ reflect.Type) []*reflectx.FieldInfo {
+	typeMap := mapper.TypeMap(typ)
+	var fields []*reflectx.FieldInfo
+	for _, fi := range typeMap.Index {
+		if fi.Embedded || fi.Name == "" || typeMap.Paths[fi.Path] != fi || !reflectx.IsColumn(fi) {
+			continue
+		}
+		if strings.Contains(fi.Path, ".") {
+			continue
+		}
+		fields = append(fields, fi)
+	}
+	return fields
+}
+
+// structValues returns the columns and values for every field of the struct
+// that is returned by structFields, except the ones with any of the given tag
+// options. The value of a field that is embedded in a nil pointer to struct is
+// nil.
+func structValues(mapper *reflectx.Mapper, v interface{}, omitOptions ...string) (columns []string, values []interface{}) {
+	structv := reflect.Indirect(reflect.ValueOf(v))
+fields:
+	for _, fi := range structFields(mapper, structv.Type()) {
+		for _, opt := range omitOptions {
+			if _, ok := fi.Options[opt]; ok {
+				continue fields
+			}
+		}
+
+		var value interface{}
+		f := structv
+		for _, i := range fi.Index {
+			if f.Kind() == reflect.Ptr {
+				if f.IsNil() {
+					f = reflect.Value{}
+					break
+				}
+				f = f.Elem()
+			}
+			f = f.Field(i)
+		}
+		if f.IsValid() {
+			value = f.Interface()
+		}
+
+		columns = append(columns, fi.Path)
+		values = append(values, value)
+	}
+	return columns, values
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// isScalar returns true if the type is scanned from a single column as a whole,
+// i.e. types other than maps and structs, and maps and structs that are
+// sql.Scanner on their own or wrapped by the typer, and time.Time.
+func isScalar(typer adapter.Typer, typ reflect.Type) bool {
+	if reflect.PtrTo(typ).Implements(scannerType) || typ == timeType {
+		return true
 	}
 
 	switch typ.Kind() {
-	case reflect.Map:
+	case reflect.Map, reflect.Struct:
+		if typer == nil {
+			return false
+		}
+		_, ok := typer.Scanner(reflect.New(typ).Interface()).(sql.Scanner)
+		return ok
+	}
+	return true
+}
+
+// resultScanner scans rows of a query into new values of the type.
+type resultScanner struct {
+	typer   adapter.Typer
+	typ     reflect.Type
+	columns []string
+	// pointer indicates whether the type is a pointer to struct, e.g. *User,
+	// whose struct is allocated for every row.
+	pointer bool
+	// scalar indicates whether the target is scanned from a single column as
+	// a whole, see isScalar.
+	scalar bool
+	// structs is used for structs and pointers to struct, see cachedScanPlan.
+	structs *structScanner
+	// scanTypes is the list of Go types to scan columns into for maps of
+	// interface{}, where nil means the column type is unrecognizable.
+	scanTypes []reflect.Type
+}
+
+// newResultScanner returns a new resultScanner for the type with given columns
+// of the rows.
+func newResultScanner(plans *cache.LRU, typer adapter.Typer, mapper *reflectx.Mapper, rows adapter.Rows, typ reflect.Type, columns []string) (*resultScanner, error) {
+	s := &resultScanner{
+		typer:   typer,
+		typ:     typ,
+		columns: columns,
+	}
+	if plan := cachedScanPlan(plans, typer, mapper, typ, columns); plan != nil {
+		s.structs = plan.newScanner()
+	}
+
+	target := typ
+	if typ.Kind() == reflect.Ptr && typ.Elem().Kind() == reflect.Struct && !isScalar(typer, typ.Elem()) {
+		s.pointer = true
+		target = typ.Elem()
+	}
+	s.scalar = isScalar(typer, target)
+
+	if typ.Kind() == reflect.Map && typ.Elem().Kind() == reflect.Interface && !s.scalar {
+		columnTypes, err := rows.ColumnTypes()
+		if err != nil {
+			return nil, errors.Wrap(err, "get column types")
+		}
+		s.scanTypes = make([]reflect.Type, len(columnTypes))
+		for i := range columnTypes {
+			s.scanTypes[i] = typer.ScanType(columnTypes[i])
+		}
+	}
+	return s, nil
+}
+
+// scan scans the current row into a new value of the type, and returns the
+// pointer to the value.
+func (s *resultScanner) scan(rows adapter.Rows) (reflect.Value, error) {
+	typer, typ, columns := s.typer, s.typ, s.columns
+	result := reflect.New(typ)
+
+	// Allocate the struct for a pointer to struct, e.g. *User
+	target := result
+	if s.pointer {
+		target = reflect.New(typ.Elem())
+		result.Elem().Set(target)
+	}
+
+	switch {
+	case s.scalar:
+		if len(columns) != 1 {
+			return reflect.Value{}, errors.Errorf("cannot scan %d columns into the scalar type %v", len(columns), typ)
+		}
+		if err := rows.Scan(typer.Scanner(target.Interface())); err != nil {
+			return reflect.Value{}, errors.Wrap(err, "scan")
+		}
+		return result, nil
+
+	case target.Type().Elem().Kind() == reflect.Map:
+		mapType := target.Type().Elem()
 		values := make([]interface{}, len(columns))
+		typed := make([]*deferredField, len(columns))
 		for i := range values {
-			if typ.Elem().Kind() == reflect.Interface {
+			switch {
+			case mapType.Elem().Kind() != reflect.Interface:
+				values[i] = reflect.New(mapType.Elem()).Interface()
+			case i < len(s.scanTypes) && s.scanTypes[i] != nil:
+				// Values of recognized column types are scanned into their Go types,
+				// and NULL values are kept as nil.
+				typed[i], values[i] = newDeferredField(typer, s.scanTypes[i], nil)
+			default:
 				values[i] = new(interface{})
-			} else {
-				values[i] = reflect.New(typ.Elem()).Interface()
 			}
 		}
 
-		if err = rows.Scan(values...); err != nil {
+		if err := rows.Scan(values...); err != nil {
 			return reflect.Value{}, errors.Wrap(err, "scan")
 		}
 
+		m := reflect.MakeMap(mapType)
 		for i, column := range columns {
-			result.SetMapIndex(reflect.ValueOf(column), reflect.Indirect(reflect.ValueOf(values[i])))
+			v := reflect.Indirect(reflect.ValueOf(values[i]))
+			if typed[i] != nil {
+				var ok bool
+				v, ok = typed[i].value()
+				if !ok {
+					v = reflect.Zero(mapType.Elem())
+				}
+			}
+			m.SetMapIndex(reflect.ValueOf(column), v)
 		}
+		target.Elem().Set(m)
 		return result, nil
+	}
 
-	case reflect.Struct:
-		values := make([]interface{}, len(columns))
-		typeMap := defaultMapper.TypeMap(typ)
-		fieldMap := typeMap.Names
-		for i, k := range columns {
-			fi, ok := fieldMap[k]
-			if !ok {
-				values[i] = new(interface{})
-				continue
+	if err := s.structs.scan(typer, rows, target); err != nil {
+		return reflect.Value{}, err
+	}
+	return result, nil
+}
+
+// deferredField is a field that is nested in a pointer to struct. It is only
+// set when the scanned value is not NULL, so that the pointer stays nil when
+// all of its fields are NULL, e.g. the result of a LEFT JOIN.
+type deferredField struct {
+	index []int
+	// value returns the scanned value and whether it is not NULL.
+	value func() (reflect.Value, bool)
+}
+
+// nullableScanner is a sql.Scanner that records whether the value is NULL, and
+// leaves the destination untouched for NULL values.
+type nullableScanner struct {
+	dest  sql.Scanner
+	valid bool
+}
+
+func (s *nullableScanner) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	s.valid = true
+	return s.dest.Scan(src)
+}
+
+// newDeferredField returns the deferredField of the field with given type and
+// index, and the destination to scan into.
+func newDeferredField(typer adapter.Typer, typ reflect.Type, index []int) (*deferredField, interface{}) {
+	temp := reflect.New(typ)
+	if scanner, ok := typer.Scanner(temp.Interface()).(sql.Scanner); ok {
+		ns := &nullableScanner{dest: scanner}
+		return &deferredField{
+			index: index,
+			value: func() (reflect.Value, bool) {
+				return temp.Elem(), ns.valid
+			},
+		}, ns
+	}
+
+	// Let the database/sql to allocate the value only when it is not NULL
+	holder := reflect.New(reflect.PtrTo(typ))
+	return &deferredField{
+		index: index,
+		value: func() (reflect.Value, bool) {
+			if holder.Elem().IsNil() {
+				return reflect.Value{}, false
 			}
+			return holder.Elem().Elem(), true
+		},
+	}, holder.Interface()
+}
 
-			f := reflectx.FieldByIndexes(result, fi.Index)
-			values[i] = typer.Scanner(f.Addr().Interface())
+// inPointerStruct returns true if the field is nested in a pointer to struct.
+func inPointerStruct(fi *reflectx.FieldInfo) bool {
+	for p := fi.Parent; p != nil && p.Field.Type != nil; p = p.Parent {
+		if p.Field.Type.Kind() == reflect.Ptr {
+			return true
 		}
+	}
+	return false
+}
 
-		if err = rows.Scan(values...); err != nil {
-			return reflect.Value{}, errors.Wrap(err, "scan")
+// checkMapping returns a *norm.MappingError when any required field of the
+// destination struct has no matching column, or any column has no matching
+// field in the strict mode. It is a no-op for types other than structs.
+func checkMapping(typer adapter.Typer, mapper *reflectx.Mapper, typ reflect.Type, columns []string, strict bool) error {
+	typ = reflectx.Deref(typ)
+	if typ.Kind() != reflect.Struct || isScalar(typer, typ) {
+		return nil
+	}
+
+	typeMap := mapper.TypeMap(typ)
+	present := make(map[string]bool, len(columns))
+	var unmapped []string
+	for _, column := range columns {
+		present[column] = true
+		if _, ok := typeMap.Names[column]; strict && !ok {
+			unmapped = append(unmapped, column)
 		}
-		return result, nil
 	}
-	panic("unreachable")
+
+	var missing []string
+	for _, fi := range typeMap.Index {
+		if _, ok := fi.Options["required"]; !ok || fi.Embedded || !reflectx.IsColumn(fi) {
+			continue
+		}
+		if !present[fi.Path] {
+			missing = append(missing, fi.Path)
+		}
+	}
+
+	if len(unmapped) == 0 && len(missing) == 0 {
+		return nil
+	}
+	return &norm.MappingError{
+		Type:            typ,
+		UnmappedColumns: unmapped,
+		MissingFields:   missing,
+	}
 }
 
-// fetchRows maps all the rows coming from the *sql.Rows into the given
-// destination. The typer is used to wrap custom types to satisfy sql.Scanner.
-func fetchRows(ctx context.Context, typer adapter.Typer, rows adapter.Rows, dest interface{}) error {
-	defer func() { _ = rows.Close() }()
+// afterFind calls the AfterFind hook of the scanned value, which is the pointer
+// to the value.
+func (iter *iterator) afterFind(ctx context.Context, item reflect.Value) error {
+	v := item.Interface()
+	if item.Elem().Kind() == reflect.Ptr {
+		v = item.Elem().Interface()
+	}
+
+	hook, ok := v.(norm.AfterFinder)
+	if !ok {
+		return nil
+	}
+	return errors.Wrap(hook.AfterFind(ctx, iter.db), "AfterFind")
+}
 
+// fetchRows maps all the rows of the current result set into the given
+// destination. The typer is used to wrap custom types to satisfy sql.Scanner,
+// and the mapper is used to match columns with struct fields.
+func (iter *iterator) fetchRows(ctx context.Context, dest interface{}) error {
+	typer, mapper, rows := iter.adapter.Typer(), iter.mapper, iter.cursor
 	destv := reflect.ValueOf(dest)
 	if destv.IsNil() || destv.Kind() != reflect.Ptr {
 		return errors.New("the destination must be an pointer and cannot be nil")
@@ -168,6 +569,15 @@ func fetchRows(ctx context.Context, typer adapter.Typer, rows adapter.Rows, dest
 
 	elem := destv.Elem()
 	typ := elem.Type().Elem()
+	if err = checkMapping(typer, mapper, typ, columns, iter.strict); err != nil {
+		return err
+	}
+
+	scanner, err := newResultScanner(iter.scanPlans, typer, mapper, rows, typ, columns)
+	if err != nil {
+		return err
+	}
+
 	var item reflect.Value
 	for rows.Next() {
 		select {
@@ -176,24 +586,25 @@ func fetchRows(ctx context.Context, typer adapter.Typer, rows adapter.Rows, dest
 		default:
 		}
 
-		item, err = scanResult(typer, rows, typ, columns)
+		item, err = scanner.scan(rows)
 		if err != nil {
 			return errors.Wrap(err, "scan result")
 		}
-
-		if typ.Kind() == reflect.Ptr {
-			elem = reflect.Append(elem, item)
-		} else {
-			elem = reflect.Append(elem, reflect.Indirect(item))
+		if err = iter.afterFind(ctx, item); err != nil {
+			return err
 		}
+
+		elem = reflect.Append(elem, item.Elem())
 	}
 	destv.Elem().Set(elem)
 	return rows.Err()
 }
 
-// fetchRow maps the next row coming from the *sql.Rows into the given
-// destination. The typer is used to wrap custom types to satisfy sql.Scanner.
-func fetchRow(typer adapter.Typer, rows adapter.Rows, dest interface{}) error {
+// fetchRow maps the next row of the current result set into the given
+// destination. The typer is used to wrap custom types to satisfy sql.Scanner,
+// and the mapper is used to match columns with struct fields.
+func (iter *iterator) fetchRow(ctx context.Context, dest interface{}) error {
+	typer, mapper, rows := iter.adapter.Typer(), iter.mapper, iter.cursor
 	destv := reflect.ValueOf(dest)
 	if destv.IsNil() || destv.Kind() != reflect.Ptr {
 		return errors.New("the destination must be an pointer and cannot be nil")
@@ -205,6 +616,12 @@ func fetchRow(typer adapter.Typer, rows adapter.Rows, dest interface{}) error {
 		return errors.Wrap(err, "get columns")
 	}
 
+	elem := destv.Elem()
+	typ := elem.Type()
+	if err = checkMapping(typer, mapper, typ, columns, iter.strict); err != nil {
+		return err
+	}
+
 	if !rows.Next() {
 		if err = rows.Err(); err != nil {
 			return err
@@ -212,17 +629,18 @@ func fetchRow(typer adapter.Typer, rows adapter.Rows, dest interface{}) error {
 		return sql.ErrNoRows
 	}
 
-	elem := destv.Elem()
-	typ := elem.Type()
-	item, err := scanResult(typer, rows, typ, columns)
+	scanner, err := newResultScanner(iter.scanPlans, typer, mapper, rows, typ, columns)
+	if err != nil {
+		return err
+	}
+	item, err := scanner.scan(rows)
 	if err != nil {
 		return errors.Wrap(err, "scan result")
 	}
-
-	if typ.Kind() == reflect.Ptr {
-		elem.Set(item)
-	} else {
-		elem.Set(reflect.Indirect(item))
+	if err = iter.afterFind(ctx, item); err != nil {
+		return err
 	}
+
+	elem.Set(item.Elem())
 	return nil
 }
@@ -7,11 +7,17 @@ package sqlbuilder
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"reflect"
 	"testing"
+	"time"
 
 	mockrequire "github.com/derision-test/go-mockgen/testutil/require"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"unknwon.dev/norm"
+	"unknwon.dev/norm/adapter"
 )
 
 //go:generate go-mockgen --force unknwon.dev/norm/adapter -i Adapter -i Executor -i Rows -i Typer -o mock_adapter_test.go
@@ -147,3 +153,473 @@ func TestIterator_One(t *testing.T) {
 		mockrequire.Called(t, cursor.ScanFunc)
 	})
 }
+
+func TestIterator_NestedStruct(t *testing.T) {
+	ctx := context.Background()
+
+	type Author struct {
+		ID       int64          `db:"id"`
+		Name     string         `db:"name"`
+		Nickname sql.NullString `db:"nickname"`
+	}
+	type Book struct {
+		ID      int64   `db:"id"`
+		Title   string  `db:"title"`
+		Author  *Author `db:"author,prefix=author_"`
+		Editor  *Author `db:"editor"`
+		Unknown int     `db:"-"`
+	}
+
+	typer := NewMockTyper()
+	typer.ScannerFunc.SetDefaultHook(func(v interface{}) interface{} {
+		return v
+	})
+	adapter := NewMockAdapter()
+	adapter.TyperFunc.SetDefaultReturn(typer)
+
+	// Mock two rows, the second one has NULL for all columns of the author
+	cursor := NewMockCursor()
+	cursor.ColumnsFunc.SetDefaultReturn([]string{"id", "title", "author_id", "author_name", "author_nickname", "editor.name"}, nil)
+	cursor.NextFunc.PushReturn(true)
+	cursor.NextFunc.PushReturn(true)
+	cursor.ScanFunc.PushHook(func(dest ...interface{}) error {
+		*dest[0].(*int64) = 1
+		*dest[1].(*string) = "Go"
+		id, name := int64(2), "Joe"
+		*dest[2].(**int64) = &id
+		*dest[3].(**string) = &name
+		require.NoError(t, dest[4].(sql.Scanner).Scan("Unknwon"))
+		assert.Nil(t, *dest[5].(**string)) // NULL
+		return nil
+	})
+	cursor.ScanFunc.PushHook(func(dest ...interface{}) error {
+		*dest[0].(*int64) = 3
+		*dest[1].(*string) = "Rust"
+		require.NoError(t, dest[4].(sql.Scanner).Scan(nil))
+		return nil
+	})
+
+	var books []Book
+	err := newIterator(adapter, cursor).All(ctx, &books)
+	require.NoError(t, err)
+
+	want := []Book{
+		{
+			ID:    1,
+			Title: "Go",
+			Author: &Author{
+				ID:       2,
+				Name:     "Joe",
+				Nickname: sql.NullString{String: "Unknwon", Valid: true},
+			},
+		},
+		{
+			ID:    3,
+			Title: "Rust",
+		},
+	}
+	assert.Equal(t, want, books)
+}
+
+// wrappedInt64s is a slice of int64 that only satisfies sql.Scanner when
+// wrapped by the typer.
+type wrappedInt64s []int64
+
+type wrappedInt64sScanner struct {
+	dest *wrappedInt64s
+}
+
+func (s wrappedInt64sScanner) Scan(src interface{}) error {
+	*s.dest = src.(wrappedInt64s)
+	return nil
+}
+
+func TestIterator_Scalar(t *testing.T) {
+	ctx := context.Background()
+
+	typer := NewMockTyper()
+	typer.ScannerFunc.SetDefaultHook(func(v interface{}) interface{} {
+		if v, ok := v.(*wrappedInt64s); ok {
+			return wrappedInt64sScanner{dest: v}
+		}
+		return v
+	})
+	adapter := NewMockAdapter()
+	adapter.TyperFunc.SetDefaultReturn(typer)
+
+	newCursor := func(columns []string, values ...interface{}) *MockCursor {
+		cursor := NewMockCursor()
+		cursor.ColumnsFunc.SetDefaultReturn(columns, nil)
+		for _, v := range values {
+			v := v
+			cursor.NextFunc.PushReturn(true)
+			cursor.ScanFunc.PushHook(func(dest ...interface{}) error {
+				require.Len(t, dest, 1)
+				if s, ok := dest[0].(sql.Scanner); ok {
+					return s.Scan(v)
+				}
+				reflect.ValueOf(dest[0]).Elem().Set(reflect.ValueOf(v))
+				return nil
+			})
+		}
+		return cursor
+	}
+
+	t.Run("slice of int64", func(t *testing.T) {
+		var got []int64
+		err := newIterator(adapter, newCursor([]string{"id"}, int64(1), int64(2))).All(ctx, &got)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1, 2}, got)
+	})
+
+	t.Run("slice of pointers", func(t *testing.T) {
+		one := "one"
+		var got []*string
+		err := newIterator(adapter, newCursor([]string{"name"}, &one, (*string)(nil))).All(ctx, &got)
+		require.NoError(t, err)
+		assert.Equal(t, []*string{&one, nil}, got)
+	})
+
+	t.Run("int", func(t *testing.T) {
+		var got int
+		err := newIterator(adapter, newCursor([]string{"count"}, 3)).One(ctx, &got)
+		require.NoError(t, err)
+		assert.Equal(t, 3, got)
+	})
+
+	t.Run("time", func(t *testing.T) {
+		now := time.Now()
+		var got time.Time
+		err := newIterator(adapter, newCursor([]string{"now"}, now)).One(ctx, &got)
+		require.NoError(t, err)
+		assert.Equal(t, now, got)
+	})
+
+	t.Run("sql.Scanner", func(t *testing.T) {
+		var got []sql.NullString
+		err := newIterator(adapter, newCursor([]string{"nickname"}, "Joe", nil)).All(ctx, &got)
+		require.NoError(t, err)
+		assert.Equal(t, []sql.NullString{{String: "Joe", Valid: true}, {}}, got)
+	})
+
+	t.Run("typer-wrapped", func(t *testing.T) {
+		var got wrappedInt64s
+		err := newIterator(adapter, newCursor([]string{"ids"}, wrappedInt64s{1, 2})).One(ctx, &got)
+		require.NoError(t, err)
+		assert.Equal(t, wrappedInt64s{1, 2}, got)
+	})
+
+	t.Run("multiple columns", func(t *testing.T) {
+		cursor := NewMockCursor()
+		cursor.ColumnsFunc.SetDefaultReturn([]string{"id", "name"}, nil)
+		cursor.NextFunc.PushReturn(true)
+
+		var got int64
+		err := newIterator(adapter, cursor).One(ctx, &got)
+		assert.Error(t, err)
+	})
+}
+
+func TestIterator_PointerToStruct(t *testing.T) {
+	ctx := context.Background()
+
+	typer := NewMockTyper()
+	typer.ScannerFunc.SetDefaultHook(func(v interface{}) interface{} {
+		return v
+	})
+	adapter := NewMockAdapter()
+	adapter.TyperFunc.SetDefaultReturn(typer)
+
+	cursor := NewMockCursor()
+	cursor.ColumnsFunc.SetDefaultReturn([]string{"name"}, nil)
+	cursor.NextFunc.PushReturn(true)
+	cursor.ScanFunc.PushHook(func(dest ...interface{}) error {
+		*dest[0].(*string) = "Joe"
+		return nil
+	})
+
+	type user struct {
+		Name string `db:"name"`
+	}
+	var got []*user
+	err := newIterator(adapter, cursor).All(ctx, &got)
+	require.NoError(t, err)
+	assert.Equal(t, []*user{{Name: "Joe"}}, got)
+}
+
+// columnType is an adapter.ColumnType with the database type name.
+type columnType struct {
+	name, typ string
+}
+
+func (ct columnType) Name() string                  { return ct.name }
+func (ct columnType) DatabaseTypeName() string      { return ct.typ }
+func (ct columnType) Nullable() (nullable, ok bool) { return false, false }
+
+func TestIterator_TypedMap(t *testing.T) {
+	ctx := context.Background()
+
+	typer := NewMockTyper()
+	typer.ScannerFunc.SetDefaultHook(func(v interface{}) interface{} {
+		return v
+	})
+	typer.ScanTypeFunc.SetDefaultHook(func(ct adapter.ColumnType) reflect.Type {
+		switch ct.DatabaseTypeName() {
+		case "INT8":
+			return reflect.TypeOf(int64(0))
+		case "TEXT":
+			return reflect.TypeOf("")
+		}
+		return nil
+	})
+	adp := NewMockAdapter()
+	adp.TyperFunc.SetDefaultReturn(typer)
+
+	newCursor := func() *MockCursor {
+		cursor := NewMockCursor()
+		cursor.ColumnsFunc.SetDefaultReturn([]string{"id", "name", "bio", "tags"}, nil)
+		cursor.ColumnTypesFunc.SetDefaultReturn(
+			[]adapter.ColumnType{
+				columnType{name: "id", typ: "INT8"},
+				columnType{name: "name", typ: "TEXT"},
+				columnType{name: "bio", typ: "TEXT"},
+				columnType{name: "tags", typ: "UNKNOWN"},
+			},
+			nil,
+		)
+		cursor.NextFunc.PushReturn(true)
+		cursor.ScanFunc.PushHook(func(dest ...interface{}) error {
+			*dest[0].(**int64) = new(int64)
+			**dest[0].(**int64) = 1
+			*dest[1].(**string) = new(string)
+			**dest[1].(**string) = "Joe"
+			// NULL value of "bio" leaves the destination untouched
+			*dest[3].(*interface{}) = []byte("{a,b}")
+			return nil
+		})
+		return cursor
+	}
+
+	t.Run("interface values", func(t *testing.T) {
+		var got map[string]interface{}
+		err := newIterator(adp, newCursor()).One(ctx, &got)
+		require.NoError(t, err)
+
+		want := map[string]interface{}{
+			"id":   int64(1),
+			"name": "Joe",
+			"bio":  nil,
+			"tags": []byte("{a,b}"),
+		}
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("column types error", func(t *testing.T) {
+		cursor := newCursor()
+		cursor.ColumnTypesFunc.SetDefaultReturn(nil, errors.New("closed"))
+
+		var got []map[string]interface{}
+		err := newIterator(adp, cursor).All(ctx, &got)
+		assert.EqualError(t, err, "get column types: closed")
+	})
+}
+
+func TestIterator_Mapping(t *testing.T) {
+	ctx := context.Background()
+
+	typer := NewMockTyper()
+	typer.ScannerFunc.SetDefaultHook(func(v interface{}) interface{} {
+		return v
+	})
+	adapter := NewMockAdapter()
+	adapter.TyperFunc.SetDefaultReturn(typer)
+
+	newCursor := func(columns ...string) *MockCursor {
+		cursor := NewMockCursor()
+		cursor.ColumnsFunc.SetDefaultReturn(columns, nil)
+		cursor.NextFunc.PushReturn(true)
+		return cursor
+	}
+
+	type user struct {
+		ID    int64  `db:"id,required"`
+		Name  string `db:"name"`
+		Email string `db:",required"`
+	}
+
+	t.Run("non-strict", func(t *testing.T) {
+		var dest user
+		err := newIterator(adapter, newCursor("id", "Email", "nickname")).One(ctx, &dest)
+		assert.NoError(t, err)
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		iter := newIterator(adapter, newCursor("id", "Email", "nickname", "age"))
+		iter.strict = true
+
+		var dest []*user
+		err := iter.All(ctx, &dest)
+		want := &norm.MappingError{
+			Type:            reflect.TypeOf(user{}),
+			UnmappedColumns: []string{"nickname", "age"},
+		}
+		assert.Equal(t, want, err)
+	})
+
+	t.Run("missing required fields", func(t *testing.T) {
+		var dest user
+		err := newIterator(adapter, newCursor("name", "nickname")).One(ctx, &dest)
+
+		var mappingErr *norm.MappingError
+		require.True(t, errors.As(err, &mappingErr))
+		assert.Empty(t, mappingErr.UnmappedColumns)
+		assert.Equal(t, []string{"id", "Email"}, mappingErr.MissingFields)
+	})
+
+	t.Run("error message", func(t *testing.T) {
+		iter := newIterator(adapter, newCursor("name", "nickname"))
+		iter.strict = true
+
+		var dest user
+		err := iter.One(ctx, &dest)
+		assert.EqualError(t, err, `map results to sqlbuilder.user: unmapped columns "nickname"; missing columns for required fields "id", "Email"`)
+	})
+
+	t.Run("not a struct", func(t *testing.T) {
+		iter := newIterator(adapter, newCursor("id", "name"))
+		iter.strict = true
+
+		dest := make(map[string]interface{})
+		err := iter.One(ctx, &dest)
+		assert.NoError(t, err)
+	})
+}
+
+func TestIterator_Mapper(t *testing.T) {
+	ctx := context.Background()
+
+	typer := NewMockTyper()
+	typer.ScannerFunc.SetDefaultHook(func(v interface{}) interface{} {
+		return v
+	})
+	adapter := NewMockAdapter()
+	adapter.TyperFunc.SetDefaultReturn(typer)
+
+	cursor := NewMockCursor()
+	cursor.ColumnsFunc.SetDefaultReturn([]string{"user_id", "full_name"}, nil)
+	cursor.NextFunc.PushReturn(true)
+	cursor.ScanFunc.PushHook(func(dest ...interface{}) error {
+		*dest[0].(*int64) = 1
+		*dest[1].(*string) = "Joe"
+		return nil
+	})
+
+	type user struct {
+		UserID int64
+		Name   string `col:"full_name"`
+	}
+	iter := newIterator(adapter, cursor)
+	iter.mapper = NewMapper(norm.MappingOptions{TagName: "col", NameFunc: norm.SnakeCase})
+	iter.strict = true
+
+	var got user
+	err := iter.One(ctx, &got)
+	require.NoError(t, err)
+	assert.Equal(t, user{UserID: 1, Name: "Joe"}, got)
+}
+
+func TestIterator_NextResultSet(t *testing.T) {
+	ctx := context.Background()
+
+	typer := NewMockTyper()
+	typer.ScannerFunc.SetDefaultHook(func(v interface{}) interface{} {
+		return v
+	})
+	adapter := NewMockAdapter()
+	adapter.TyperFunc.SetDefaultReturn(typer)
+
+	type user struct {
+		Name string `db:"name"`
+	}
+	type email struct {
+		Address string `db:"address"`
+	}
+
+	newCursor := func() *MockCursor {
+		cursor := NewMockCursor()
+		cursor.ColumnsFunc.PushReturn([]string{"name"}, nil)
+		cursor.ColumnsFunc.PushReturn([]string{"address"}, nil)
+		cursor.NextFunc.PushReturn(true)
+		cursor.NextFunc.PushReturn(false)
+		cursor.NextFunc.PushReturn(true)
+		cursor.ScanFunc.PushHook(func(dest ...interface{}) error {
+			*dest[0].(*string) = "Joe"
+			return nil
+		})
+		cursor.ScanFunc.PushHook(func(dest ...interface{}) error {
+			*dest[0].(*string) = "joe@example.com"
+			return nil
+		})
+		cursor.NextResultSetFunc.PushReturn(true)
+		return cursor
+	}
+
+	t.Run("map each result set", func(t *testing.T) {
+		cursor := newCursor()
+		iter := newIterator(adapter, cursor)
+
+		require.True(t, iter.NextResultSet())
+		var users []*user
+		err := iter.All(ctx, &users)
+		require.NoError(t, err)
+		assert.Equal(t, []*user{{Name: "Joe"}}, users)
+		mockrequire.NotCalled(t, cursor.CloseFunc)
+
+		require.True(t, iter.NextResultSet())
+		var got email
+		err = iter.One(ctx, &got)
+		require.NoError(t, err)
+		assert.Equal(t, email{Address: "joe@example.com"}, got)
+		mockrequire.Called(t, cursor.CloseFunc)
+
+		assert.False(t, iter.NextResultSet())
+		assert.NoError(t, iter.Err())
+	})
+
+	t.Run("closed after mapping without NextResultSet", func(t *testing.T) {
+		cursor := newCursor()
+		iter := newIterator(adapter, cursor)
+
+		var users []*user
+		err := iter.All(ctx, &users)
+		require.NoError(t, err)
+		assert.Equal(t, []*user{{Name: "Joe"}}, users)
+		mockrequire.Called(t, cursor.CloseFunc)
+		mockrequire.NotCalled(t, cursor.NextResultSetFunc)
+
+		assert.False(t, iter.NextResultSet())
+		assert.NoError(t, iter.Err())
+	})
+
+	t.Run("already mapped", func(t *testing.T) {
+		iter := newIterator(adapter, newCursor())
+		require.True(t, iter.NextResultSet())
+
+		var users []*user
+		err := iter.All(ctx, &users)
+		require.NoError(t, err)
+
+		err = iter.All(ctx, &users)
+		assert.EqualError(t, err, "the current result set is already mapped, call NextResultSet to advance")
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		iter := newIterator(adapter, newCursor())
+		require.NoError(t, iter.Close())
+
+		assert.False(t, iter.NextResultSet())
+		var users []*user
+		err := iter.All(ctx, &users)
+		assert.EqualError(t, err, "the iterator is closed")
+	})
+}
@@ -0,0 +1,87 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+
+	"unknwon.dev/norm"
+	"unknwon.dev/norm/internal/reflectx"
+)
+
+// optionOptimisticLock is the tag option of the integer field that holds the
+// version of the row for optimistic locking.
+const optionOptimisticLock = "optimisticlock"
+
+// optimisticLock is the version of a model that is passed to Updater.Set.
+type optimisticLock struct {
+	model interface{}
+	field *reflectx.FieldInfo
+	// version is the current version that the row is expected to have.
+	version interface{}
+	// next is the version that the row is updated to.
+	next reflect.Value
+}
+
+// newOptimisticLock returns the optimistic lock of the model from the given
+// struct values, or nil if none of its fields has the "optimisticlock" tag
+// option.
+func newOptimisticLock(mapper *reflectx.Mapper, model interface{}, columns []string, values []interface{}) (*optimisticLock, error) {
+	var field *reflectx.FieldInfo
+	for _, fi := range structFields(mapper, reflect.Indirect(reflect.ValueOf(model)).Type()) {
+		if _, ok := fi.Options[optionOptimisticLock]; ok {
+			field = fi
+			break
+		}
+	}
+	if field == nil {
+		return nil, nil
+	}
+
+	var version interface{}
+	for i := range columns {
+		if columns[i] == field.Path {
+			version = values[i]
+			break
+		}
+	}
+	v := reflect.ValueOf(version)
+	if !v.IsValid() {
+		return nil, errors.Errorf("field %q: optimistic lock version is not accessible", field.Field.Name)
+	}
+
+	next := reflect.New(v.Type()).Elem()
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		next.SetInt(v.Int() + 1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		next.SetUint(v.Uint() + 1)
+	default:
+		return nil, errors.Errorf("field %q: optimistic lock expects an integer but got %v", field.Field.Name, v.Type())
+	}
+	return &optimisticLock{
+		model:   model,
+		field:   field,
+		version: version,
+		next:    next,
+	}, nil
+}
+
+// stale returns the error of the stale model.
+func (l *optimisticLock) stale() error {
+	return errors.Wrapf(norm.ErrStaleObject, "update %T with version %v", l.model, l.version)
+}
+
+// advance sets the version field of the model to the next version. Only models
+// that are pointers to struct are changed.
+func (l *optimisticLock) advance() {
+	v := reflect.ValueOf(l.model)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return
+	}
+	reflectx.FieldByIndexes(v.Elem(), l.field.Index).Set(l.next)
+}
@@ -0,0 +1,92 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"unknwon.dev/norm"
+	"unknwon.dev/norm/internal/exql"
+)
+
+func TestOptimisticLock(t *testing.T) {
+	ctx := context.Background()
+
+	type post struct {
+		Title   string `db:"title"`
+		Version int    `db:"version,optimisticlock"`
+	}
+
+	newSQL := func(affected int64, gotArgs *[]interface{}) *sqlBuilder {
+		executor := NewMockExecutor()
+		executor.ExecFunc.SetDefaultHook(func(_ context.Context, _ *exql.Statement, args ...interface{}) (sql.Result, error) {
+			*gotArgs = args
+			return driverResult(affected), nil
+		})
+
+		typer := NewMockTyper()
+		typer.ValuerFunc.SetDefaultHook(func(v interface{}) interface{} {
+			return v
+		})
+
+		adapter := NewMockAdapter()
+		adapter.ExecutorFunc.SetDefaultReturn(executor)
+		adapter.TyperFunc.SetDefaultReturn(typer)
+		adapter.FormatSQLFunc.SetDefaultHook(func(sql string) string {
+			return exql.StripWhitespace(sql)
+		})
+		return New(adapter, defaultTemplate(t)).(*sqlBuilder)
+	}
+
+	t.Run("query", func(t *testing.T) {
+		var args []interface{}
+		updater := newSQL(1, &args).
+			Update("posts").
+			Set(&post{Title: "Hello", Version: 2}).
+			Where("id = ? OR slug = ?", 1, "hello")
+		assert.Equal(t, `UPDATE "posts" SET "title" = ?, "version" = ? WHERE (id = ? OR slug = ?) AND "version" = ?`, updater.String())
+		assert.Equal(t, []interface{}{"Hello", 3, 1, "hello", 2}, updater.Arguments())
+	})
+
+	t.Run("updated", func(t *testing.T) {
+		var args []interface{}
+		p := &post{Title: "Hello", Version: 2}
+		_, err := newSQL(1, &args).Update("posts").Set(p).Where("id = ?", 1).Exec(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, &post{Title: "Hello", Version: 3}, p)
+		assert.Equal(t, []interface{}{"Hello", 3, 1, 2}, args)
+	})
+
+	t.Run("stale", func(t *testing.T) {
+		var args []interface{}
+		p := &post{Title: "Hello", Version: 2}
+		_, err := newSQL(0, &args).Update("posts").Set(p).Where("id = ?", 1).Exec(ctx)
+		assert.True(t, errors.Is(err, norm.ErrStaleObject))
+		assert.EqualError(t, err, "update *sqlbuilder.post with version 2: stale object")
+		assert.Equal(t, &post{Title: "Hello", Version: 2}, p)
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		type comment struct {
+			Version string `db:"version,optimisticlock"`
+		}
+
+		var args []interface{}
+		_, err := newSQL(1, &args).Update("comments").Set(&comment{}).Exec(ctx)
+		assert.EqualError(t, err, `build query: construct *updaterQuery: Set: *sqlbuilder.comment: field "Version": optimistic lock expects an integer but got string`)
+	})
+}
+
+type driverResult int64
+
+func (r driverResult) LastInsertId() (int64, error) { return 0, nil }
+func (r driverResult) RowsAffected() (int64, error) { return int64(r), nil }
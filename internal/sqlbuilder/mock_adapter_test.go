@@ -5,6 +5,7 @@ package sqlbuilder
 import (
 	"context"
 	"database/sql"
+	"reflect"
 	"sync"
 
 	adapter "unknwon.dev/norm/adapter"
@@ -20,6 +21,9 @@ type MockAdapter struct {
 	// FormatSQLFunc is an instance of a mock function object controlling
 	// the behavior of the method FormatSQL.
 	FormatSQLFunc *AdapterFormatSQLFunc
+	// IntrospectorFunc is an instance of a mock function object controlling
+	// the behavior of the method Introspector.
+	IntrospectorFunc *AdapterIntrospectorFunc
 	// NameFunc is an instance of a mock function object controlling the
 	// behavior of the method Name.
 	NameFunc *AdapterNameFunc
@@ -42,6 +46,11 @@ func NewMockAdapter() *MockAdapter {
 				return ""
 			},
 		},
+		IntrospectorFunc: &AdapterIntrospectorFunc{
+			defaultHook: func() adapter.Introspector {
+				return nil
+			},
+		},
 		NameFunc: &AdapterNameFunc{
 			defaultHook: func() adapter.Name {
 				return ""
@@ -69,6 +78,11 @@ func NewStrictMockAdapter() *MockAdapter {
 				panic("unexpected invocation of MockAdapter.FormatSQL")
 			},
 		},
+		IntrospectorFunc: &AdapterIntrospectorFunc{
+			defaultHook: func() adapter.Introspector {
+				panic("unexpected invocation of MockAdapter.Introspector")
+			},
+		},
 		NameFunc: &AdapterNameFunc{
 			defaultHook: func() adapter.Name {
 				panic("unexpected invocation of MockAdapter.Name")
@@ -92,6 +106,9 @@ func NewMockAdapterFrom(i adapter.Adapter) *MockAdapter {
 		FormatSQLFunc: &AdapterFormatSQLFunc{
 			defaultHook: i.FormatSQL,
 		},
+		IntrospectorFunc: &AdapterIntrospectorFunc{
+			defaultHook: i.Introspector,
+		},
 		NameFunc: &AdapterNameFunc{
 			defaultHook: i.Name,
 		},
@@ -302,6 +319,106 @@ func (c AdapterFormatSQLFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// AdapterIntrospectorFunc describes the behavior when the Introspector
+// method of the parent MockAdapter instance is invoked.
+type AdapterIntrospectorFunc struct {
+	defaultHook func() adapter.Introspector
+	hooks       []func() adapter.Introspector
+	history     []AdapterIntrospectorFuncCall
+	mutex       sync.Mutex
+}
+
+// Introspector delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockAdapter) Introspector() adapter.Introspector {
+	r0 := m.IntrospectorFunc.nextHook()()
+	m.IntrospectorFunc.appendCall(AdapterIntrospectorFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Introspector method
+// of the parent MockAdapter instance is invoked and the hook queue is
+// empty.
+func (f *AdapterIntrospectorFunc) SetDefaultHook(hook func() adapter.Introspector) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Introspector method of the parent MockAdapter instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *AdapterIntrospectorFunc) PushHook(hook func() adapter.Introspector) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *AdapterIntrospectorFunc) SetDefaultReturn(r0 adapter.Introspector) {
+	f.SetDefaultHook(func() adapter.Introspector {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *AdapterIntrospectorFunc) PushReturn(r0 adapter.Introspector) {
+	f.PushHook(func() adapter.Introspector {
+		return r0
+	})
+}
+
+func (f *AdapterIntrospectorFunc) nextHook() func() adapter.Introspector {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AdapterIntrospectorFunc) appendCall(r0 AdapterIntrospectorFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AdapterIntrospectorFuncCall objects
+// describing the invocations of this function.
+func (f *AdapterIntrospectorFunc) History() []AdapterIntrospectorFuncCall {
+	f.mutex.Lock()
+	history := make([]AdapterIntrospectorFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AdapterIntrospectorFuncCall is an object that describes an invocation of
+// method Introspector on an instance of MockAdapter.
+type AdapterIntrospectorFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 adapter.Introspector
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AdapterIntrospectorFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AdapterIntrospectorFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // AdapterNameFunc describes the behavior when the Name method of the parent
 // MockAdapter instance is invoked.
 type AdapterNameFunc struct {
@@ -1058,6 +1175,9 @@ type MockRows struct {
 	// CloseFunc is an instance of a mock function object controlling the
 	// behavior of the method Close.
 	CloseFunc *RowsCloseFunc
+	// ColumnTypesFunc is an instance of a mock function object controlling
+	// the behavior of the method ColumnTypes.
+	ColumnTypesFunc *RowsColumnTypesFunc
 	// ColumnsFunc is an instance of a mock function object controlling the
 	// behavior of the method Columns.
 	ColumnsFunc *RowsColumnsFunc
@@ -1067,6 +1187,9 @@ type MockRows struct {
 	// NextFunc is an instance of a mock function object controlling the
 	// behavior of the method Next.
 	NextFunc *RowsNextFunc
+	// NextResultSetFunc is an instance of a mock function object
+	// controlling the behavior of the method NextResultSet.
+	NextResultSetFunc *RowsNextResultSetFunc
 	// ScanFunc is an instance of a mock function object controlling the
 	// behavior of the method Scan.
 	ScanFunc *RowsScanFunc
@@ -1081,6 +1204,11 @@ func NewMockRows() *MockRows {
 				return nil
 			},
 		},
+		ColumnTypesFunc: &RowsColumnTypesFunc{
+			defaultHook: func() ([]adapter.ColumnType, error) {
+				return nil, nil
+			},
+		},
 		ColumnsFunc: &RowsColumnsFunc{
 			defaultHook: func() ([]string, error) {
 				return nil, nil
@@ -1096,6 +1224,11 @@ func NewMockRows() *MockRows {
 				return false
 			},
 		},
+		NextResultSetFunc: &RowsNextResultSetFunc{
+			defaultHook: func() bool {
+				return false
+			},
+		},
 		ScanFunc: &RowsScanFunc{
 			defaultHook: func(...interface{}) error {
 				return nil
@@ -1113,6 +1246,11 @@ func NewStrictMockRows() *MockRows {
 				panic("unexpected invocation of MockRows.Close")
 			},
 		},
+		ColumnTypesFunc: &RowsColumnTypesFunc{
+			defaultHook: func() ([]adapter.ColumnType, error) {
+				panic("unexpected invocation of MockRows.ColumnTypes")
+			},
+		},
 		ColumnsFunc: &RowsColumnsFunc{
 			defaultHook: func() ([]string, error) {
 				panic("unexpected invocation of MockRows.Columns")
@@ -1128,6 +1266,11 @@ func NewStrictMockRows() *MockRows {
 				panic("unexpected invocation of MockRows.Next")
 			},
 		},
+		NextResultSetFunc: &RowsNextResultSetFunc{
+			defaultHook: func() bool {
+				panic("unexpected invocation of MockRows.NextResultSet")
+			},
+		},
 		ScanFunc: &RowsScanFunc{
 			defaultHook: func(...interface{}) error {
 				panic("unexpected invocation of MockRows.Scan")
@@ -1143,6 +1286,9 @@ func NewMockRowsFrom(i adapter.Rows) *MockRows {
 		CloseFunc: &RowsCloseFunc{
 			defaultHook: i.Close,
 		},
+		ColumnTypesFunc: &RowsColumnTypesFunc{
+			defaultHook: i.ColumnTypes,
+		},
 		ColumnsFunc: &RowsColumnsFunc{
 			defaultHook: i.Columns,
 		},
@@ -1152,6 +1298,9 @@ func NewMockRowsFrom(i adapter.Rows) *MockRows {
 		NextFunc: &RowsNextFunc{
 			defaultHook: i.Next,
 		},
+		NextResultSetFunc: &RowsNextResultSetFunc{
+			defaultHook: i.NextResultSet,
+		},
 		ScanFunc: &RowsScanFunc{
 			defaultHook: i.Scan,
 		},
@@ -1257,6 +1406,108 @@ func (c RowsCloseFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// RowsColumnTypesFunc describes the behavior when the ColumnTypes method of
+// the parent MockRows instance is invoked.
+type RowsColumnTypesFunc struct {
+	defaultHook func() ([]adapter.ColumnType, error)
+	hooks       []func() ([]adapter.ColumnType, error)
+	history     []RowsColumnTypesFuncCall
+	mutex       sync.Mutex
+}
+
+// ColumnTypes delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockRows) ColumnTypes() ([]adapter.ColumnType, error) {
+	r0, r1 := m.ColumnTypesFunc.nextHook()()
+	m.ColumnTypesFunc.appendCall(RowsColumnTypesFuncCall{r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ColumnTypes method
+// of the parent MockRows instance is invoked and the hook queue is empty.
+func (f *RowsColumnTypesFunc) SetDefaultHook(hook func() ([]adapter.ColumnType, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ColumnTypes method of the parent MockRows instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *RowsColumnTypesFunc) PushHook(hook func() ([]adapter.ColumnType, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *RowsColumnTypesFunc) SetDefaultReturn(r0 []adapter.ColumnType, r1 error) {
+	f.SetDefaultHook(func() ([]adapter.ColumnType, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *RowsColumnTypesFunc) PushReturn(r0 []adapter.ColumnType, r1 error) {
+	f.PushHook(func() ([]adapter.ColumnType, error) {
+		return r0, r1
+	})
+}
+
+func (f *RowsColumnTypesFunc) nextHook() func() ([]adapter.ColumnType, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RowsColumnTypesFunc) appendCall(r0 RowsColumnTypesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RowsColumnTypesFuncCall objects describing
+// the invocations of this function.
+func (f *RowsColumnTypesFunc) History() []RowsColumnTypesFuncCall {
+	f.mutex.Lock()
+	history := make([]RowsColumnTypesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RowsColumnTypesFuncCall is an object that describes an invocation of
+// method ColumnTypes on an instance of MockRows.
+type RowsColumnTypesFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []adapter.ColumnType
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RowsColumnTypesFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RowsColumnTypesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // RowsColumnsFunc describes the behavior when the Columns method of the
 // parent MockRows instance is invoked.
 type RowsColumnsFunc struct {
@@ -1557,6 +1808,105 @@ func (c RowsNextFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// RowsNextResultSetFunc describes the behavior when the NextResultSet
+// method of the parent MockRows instance is invoked.
+type RowsNextResultSetFunc struct {
+	defaultHook func() bool
+	hooks       []func() bool
+	history     []RowsNextResultSetFuncCall
+	mutex       sync.Mutex
+}
+
+// NextResultSet delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockRows) NextResultSet() bool {
+	r0 := m.NextResultSetFunc.nextHook()()
+	m.NextResultSetFunc.appendCall(RowsNextResultSetFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the NextResultSet method
+// of the parent MockRows instance is invoked and the hook queue is empty.
+func (f *RowsNextResultSetFunc) SetDefaultHook(hook func() bool) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// NextResultSet method of the parent MockRows instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *RowsNextResultSetFunc) PushHook(hook func() bool) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *RowsNextResultSetFunc) SetDefaultReturn(r0 bool) {
+	f.SetDefaultHook(func() bool {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *RowsNextResultSetFunc) PushReturn(r0 bool) {
+	f.PushHook(func() bool {
+		return r0
+	})
+}
+
+func (f *RowsNextResultSetFunc) nextHook() func() bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RowsNextResultSetFunc) appendCall(r0 RowsNextResultSetFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RowsNextResultSetFuncCall objects
+// describing the invocations of this function.
+func (f *RowsNextResultSetFunc) History() []RowsNextResultSetFuncCall {
+	f.mutex.Lock()
+	history := make([]RowsNextResultSetFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RowsNextResultSetFuncCall is an object that describes an invocation of
+// method NextResultSet on an instance of MockRows.
+type RowsNextResultSetFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RowsNextResultSetFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RowsNextResultSetFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // RowsScanFunc describes the behavior when the Scan method of the parent
 // MockRows instance is invoked.
 type RowsScanFunc struct {
@@ -1669,6 +2019,9 @@ func (c RowsScanFuncCall) Results() []interface{} {
 // MockTyper is a mock implementation of the Typer interface (from the
 // package unknwon.dev/norm/adapter) used for unit testing.
 type MockTyper struct {
+	// ScanTypeFunc is an instance of a mock function object controlling the
+	// behavior of the method ScanType.
+	ScanTypeFunc *TyperScanTypeFunc
 	// ScannerFunc is an instance of a mock function object controlling the
 	// behavior of the method Scanner.
 	ScannerFunc *TyperScannerFunc
@@ -1681,6 +2034,11 @@ type MockTyper struct {
 // return zero values for all results, unless overwritten.
 func NewMockTyper() *MockTyper {
 	return &MockTyper{
+		ScanTypeFunc: &TyperScanTypeFunc{
+			defaultHook: func(adapter.ColumnType) reflect.Type {
+				return nil
+			},
+		},
 		ScannerFunc: &TyperScannerFunc{
 			defaultHook: func(interface{}) interface{} {
 				return nil
@@ -1698,6 +2056,11 @@ func NewMockTyper() *MockTyper {
 // panic on invocation, unless overwritten.
 func NewStrictMockTyper() *MockTyper {
 	return &MockTyper{
+		ScanTypeFunc: &TyperScanTypeFunc{
+			defaultHook: func(adapter.ColumnType) reflect.Type {
+				panic("unexpected invocation of MockTyper.ScanType")
+			},
+		},
 		ScannerFunc: &TyperScannerFunc{
 			defaultHook: func(interface{}) interface{} {
 				panic("unexpected invocation of MockTyper.Scanner")
@@ -1715,6 +2078,9 @@ func NewStrictMockTyper() *MockTyper {
 // methods delegate to the given implementation, unless overwritten.
 func NewMockTyperFrom(i adapter.Typer) *MockTyper {
 	return &MockTyper{
+		ScanTypeFunc: &TyperScanTypeFunc{
+			defaultHook: i.ScanType,
+		},
 		ScannerFunc: &TyperScannerFunc{
 			defaultHook: i.Scanner,
 		},
@@ -1724,6 +2090,108 @@ func NewMockTyperFrom(i adapter.Typer) *MockTyper {
 	}
 }
 
+// TyperScanTypeFunc describes the behavior when the ScanType method of the
+// parent MockTyper instance is invoked.
+type TyperScanTypeFunc struct {
+	defaultHook func(adapter.ColumnType) reflect.Type
+	hooks       []func(adapter.ColumnType) reflect.Type
+	history     []TyperScanTypeFuncCall
+	mutex       sync.Mutex
+}
+
+// ScanType delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockTyper) ScanType(v0 adapter.ColumnType) reflect.Type {
+	r0 := m.ScanTypeFunc.nextHook()(v0)
+	m.ScanTypeFunc.appendCall(TyperScanTypeFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the ScanType method of
+// the parent MockTyper instance is invoked and the hook queue is empty.
+func (f *TyperScanTypeFunc) SetDefaultHook(hook func(adapter.ColumnType) reflect.Type) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ScanType method of the parent MockTyper instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *TyperScanTypeFunc) PushHook(hook func(adapter.ColumnType) reflect.Type) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *TyperScanTypeFunc) SetDefaultReturn(r0 reflect.Type) {
+	f.SetDefaultHook(func(adapter.ColumnType) reflect.Type {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *TyperScanTypeFunc) PushReturn(r0 reflect.Type) {
+	f.PushHook(func(adapter.ColumnType) reflect.Type {
+		return r0
+	})
+}
+
+func (f *TyperScanTypeFunc) nextHook() func(adapter.ColumnType) reflect.Type {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *TyperScanTypeFunc) appendCall(r0 TyperScanTypeFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of TyperScanTypeFuncCall objects describing
+// the invocations of this function.
+func (f *TyperScanTypeFunc) History() []TyperScanTypeFuncCall {
+	f.mutex.Lock()
+	history := make([]TyperScanTypeFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// TyperScanTypeFuncCall is an object that describes an invocation of method
+// ScanType on an instance of MockTyper.
+type TyperScanTypeFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 adapter.ColumnType
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 reflect.Type
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c TyperScanTypeFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c TyperScanTypeFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // TyperScannerFunc describes the behavior when the Scanner method of the
 // parent MockTyper instance is invoked.
 type TyperScannerFunc struct {
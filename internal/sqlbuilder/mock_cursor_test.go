@@ -2,7 +2,11 @@
 
 package sqlbuilder
 
-import "sync"
+import (
+	"sync"
+
+	adapter "unknwon.dev/norm/adapter"
+)
 
 // MockCursor is a mock implementation of the cursor interface (from the
 // package unknwon.dev/norm/internal/sqlbuilder) used for unit testing.
@@ -10,6 +14,9 @@ type MockCursor struct {
 	// CloseFunc is an instance of a mock function object controlling the
 	// behavior of the method Close.
 	CloseFunc *CursorCloseFunc
+	// ColumnTypesFunc is an instance of a mock function object controlling
+	// the behavior of the method ColumnTypes.
+	ColumnTypesFunc *CursorColumnTypesFunc
 	// ColumnsFunc is an instance of a mock function object controlling the
 	// behavior of the method Columns.
 	ColumnsFunc *CursorColumnsFunc
@@ -19,6 +26,9 @@ type MockCursor struct {
 	// NextFunc is an instance of a mock function object controlling the
 	// behavior of the method Next.
 	NextFunc *CursorNextFunc
+	// NextResultSetFunc is an instance of a mock function object
+	// controlling the behavior of the method NextResultSet.
+	NextResultSetFunc *CursorNextResultSetFunc
 	// ScanFunc is an instance of a mock function object controlling the
 	// behavior of the method Scan.
 	ScanFunc *CursorScanFunc
@@ -33,6 +43,11 @@ func NewMockCursor() *MockCursor {
 				return nil
 			},
 		},
+		ColumnTypesFunc: &CursorColumnTypesFunc{
+			defaultHook: func() ([]adapter.ColumnType, error) {
+				return nil, nil
+			},
+		},
 		ColumnsFunc: &CursorColumnsFunc{
 			defaultHook: func() ([]string, error) {
 				return nil, nil
@@ -48,6 +63,11 @@ func NewMockCursor() *MockCursor {
 				return false
 			},
 		},
+		NextResultSetFunc: &CursorNextResultSetFunc{
+			defaultHook: func() bool {
+				return false
+			},
+		},
 		ScanFunc: &CursorScanFunc{
 			defaultHook: func(...interface{}) error {
 				return nil
@@ -65,6 +85,11 @@ func NewStrictMockCursor() *MockCursor {
 				panic("unexpected invocation of MockCursor.Close")
 			},
 		},
+		ColumnTypesFunc: &CursorColumnTypesFunc{
+			defaultHook: func() ([]adapter.ColumnType, error) {
+				panic("unexpected invocation of MockCursor.ColumnTypes")
+			},
+		},
 		ColumnsFunc: &CursorColumnsFunc{
 			defaultHook: func() ([]string, error) {
 				panic("unexpected invocation of MockCursor.Columns")
@@ -80,6 +105,11 @@ func NewStrictMockCursor() *MockCursor {
 				panic("unexpected invocation of MockCursor.Next")
 			},
 		},
+		NextResultSetFunc: &CursorNextResultSetFunc{
+			defaultHook: func() bool {
+				panic("unexpected invocation of MockCursor.NextResultSet")
+			},
+		},
 		ScanFunc: &CursorScanFunc{
 			defaultHook: func(...interface{}) error {
 				panic("unexpected invocation of MockCursor.Scan")
@@ -93,9 +123,11 @@ func NewStrictMockCursor() *MockCursor {
 // unexported in the source package.
 type surrogateMockCursor interface {
 	Close() error
+	ColumnTypes() ([]adapter.ColumnType, error)
 	Columns() ([]string, error)
 	Err() error
 	Next() bool
+	NextResultSet() bool
 	Scan(...interface{}) error
 }
 
@@ -106,6 +138,9 @@ func NewMockCursorFrom(i surrogateMockCursor) *MockCursor {
 		CloseFunc: &CursorCloseFunc{
 			defaultHook: i.Close,
 		},
+		ColumnTypesFunc: &CursorColumnTypesFunc{
+			defaultHook: i.ColumnTypes,
+		},
 		ColumnsFunc: &CursorColumnsFunc{
 			defaultHook: i.Columns,
 		},
@@ -115,6 +150,9 @@ func NewMockCursorFrom(i surrogateMockCursor) *MockCursor {
 		NextFunc: &CursorNextFunc{
 			defaultHook: i.Next,
 		},
+		NextResultSetFunc: &CursorNextResultSetFunc{
+			defaultHook: i.NextResultSet,
+		},
 		ScanFunc: &CursorScanFunc{
 			defaultHook: i.Scan,
 		},
@@ -220,6 +258,108 @@ func (c CursorCloseFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// CursorColumnTypesFunc describes the behavior when the ColumnTypes method
+// of the parent MockCursor instance is invoked.
+type CursorColumnTypesFunc struct {
+	defaultHook func() ([]adapter.ColumnType, error)
+	hooks       []func() ([]adapter.ColumnType, error)
+	history     []CursorColumnTypesFuncCall
+	mutex       sync.Mutex
+}
+
+// ColumnTypes delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockCursor) ColumnTypes() ([]adapter.ColumnType, error) {
+	r0, r1 := m.ColumnTypesFunc.nextHook()()
+	m.ColumnTypesFunc.appendCall(CursorColumnTypesFuncCall{r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ColumnTypes method
+// of the parent MockCursor instance is invoked and the hook queue is empty.
+func (f *CursorColumnTypesFunc) SetDefaultHook(hook func() ([]adapter.ColumnType, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ColumnTypes method of the parent MockCursor instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *CursorColumnTypesFunc) PushHook(hook func() ([]adapter.ColumnType, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *CursorColumnTypesFunc) SetDefaultReturn(r0 []adapter.ColumnType, r1 error) {
+	f.SetDefaultHook(func() ([]adapter.ColumnType, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *CursorColumnTypesFunc) PushReturn(r0 []adapter.ColumnType, r1 error) {
+	f.PushHook(func() ([]adapter.ColumnType, error) {
+		return r0, r1
+	})
+}
+
+func (f *CursorColumnTypesFunc) nextHook() func() ([]adapter.ColumnType, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CursorColumnTypesFunc) appendCall(r0 CursorColumnTypesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CursorColumnTypesFuncCall objects
+// describing the invocations of this function.
+func (f *CursorColumnTypesFunc) History() []CursorColumnTypesFuncCall {
+	f.mutex.Lock()
+	history := make([]CursorColumnTypesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CursorColumnTypesFuncCall is an object that describes an invocation of
+// method ColumnTypes on an instance of MockCursor.
+type CursorColumnTypesFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []adapter.ColumnType
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CursorColumnTypesFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CursorColumnTypesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CursorColumnsFunc describes the behavior when the Columns method of the
 // parent MockCursor instance is invoked.
 type CursorColumnsFunc struct {
@@ -520,6 +660,105 @@ func (c CursorNextFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// CursorNextResultSetFunc describes the behavior when the NextResultSet
+// method of the parent MockCursor instance is invoked.
+type CursorNextResultSetFunc struct {
+	defaultHook func() bool
+	hooks       []func() bool
+	history     []CursorNextResultSetFuncCall
+	mutex       sync.Mutex
+}
+
+// NextResultSet delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockCursor) NextResultSet() bool {
+	r0 := m.NextResultSetFunc.nextHook()()
+	m.NextResultSetFunc.appendCall(CursorNextResultSetFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the NextResultSet method
+// of the parent MockCursor instance is invoked and the hook queue is empty.
+func (f *CursorNextResultSetFunc) SetDefaultHook(hook func() bool) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// NextResultSet method of the parent MockCursor instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *CursorNextResultSetFunc) PushHook(hook func() bool) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *CursorNextResultSetFunc) SetDefaultReturn(r0 bool) {
+	f.SetDefaultHook(func() bool {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *CursorNextResultSetFunc) PushReturn(r0 bool) {
+	f.PushHook(func() bool {
+		return r0
+	})
+}
+
+func (f *CursorNextResultSetFunc) nextHook() func() bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CursorNextResultSetFunc) appendCall(r0 CursorNextResultSetFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CursorNextResultSetFuncCall objects
+// describing the invocations of this function.
+func (f *CursorNextResultSetFunc) History() []CursorNextResultSetFuncCall {
+	f.mutex.Lock()
+	history := make([]CursorNextResultSetFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CursorNextResultSetFuncCall is an object that describes an invocation of
+// method NextResultSet on an instance of MockCursor.
+type CursorNextResultSetFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CursorNextResultSetFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CursorNextResultSetFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // CursorScanFunc describes the behavior when the Scan method of the parent
 // MockCursor instance is invoked.
 type CursorScanFunc struct {
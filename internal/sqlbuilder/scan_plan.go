@@ -0,0 +1,179 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"unknwon.dev/norm/adapter"
+	"unknwon.dev/norm/internal/cache"
+	"unknwon.dev/norm/internal/reflectx"
+)
+
+// scanPlan is the plan to scan rows with a set of columns into a struct type.
+// It resolves the column-to-field mapping and whether fields need to be wrapped
+// by the typer once, so that scanning each row only allocates the destinations.
+type scanPlan struct {
+	// fields is the list of fields in the order of columns, where nil means the
+	// column has no matching field.
+	fields []*scanField
+	// deferred indicates whether any field is nested in a pointer to struct.
+	deferred bool
+}
+
+// scanField is a field of the struct to scan a column into.
+type scanField struct {
+	index []int
+	typ   reflect.Type
+	// deferred indicates whether the field is nested in a pointer to struct, see
+	// deferredField for details.
+	deferred bool
+	// wrapped indicates whether the address of the field needs to be wrapped by
+	// the typer to satisfy sql.Scanner.
+	wrapped bool
+}
+
+// scanPlanKey is the key of cached scan plans.
+type scanPlanKey struct {
+	typer   adapter.Typer
+	mapper  *reflectx.Mapper
+	typ     reflect.Type
+	columns []string
+}
+
+// Hash returns the identity of the key. The mapper and the type are identified
+// by their addresses. Typers are normally stateless values, thus they are
+// identified by their types, plus the addresses for pointers.
+func (k scanPlanKey) Hash() string {
+	var b strings.Builder
+	typer := reflect.ValueOf(k.typer)
+	b.WriteString(typer.Type().String())
+	if typer.Kind() == reflect.Ptr {
+		_, _ = fmt.Fprintf(&b, "(%p)", k.typer)
+	}
+	_, _ = fmt.Fprintf(&b, "\x00%p\x00%p", k.mapper, k.typ)
+	for _, column := range k.columns {
+		b.WriteByte(0)
+		b.WriteString(column)
+	}
+	return b.String()
+}
+
+// NewScanPlanCache returns a new cache of plans to scan rows into structs. The
+// cache holds a bounded number of plans, and it should be shared by query
+// builders of the same database.
+func NewScanPlanCache() *cache.LRU {
+	return cache.NewLRU(cache.Options{Capacity: 512})
+}
+
+// newScanPlan builds the scan plan for the struct type with given columns.
+func newScanPlan(typer adapter.Typer, mapper *reflectx.Mapper, typ reflect.Type, columns []string) *scanPlan {
+	fieldMap := mapper.TypeMap(typ).Names
+	plan := &scanPlan{
+		fields: make([]*scanField, len(columns)),
+	}
+	for i, column := range columns {
+		fi, ok := fieldMap[column]
+		if !ok {
+			continue
+		}
+
+		ptr := reflect.New(fi.Field.Type).Interface()
+		sf := &scanField{
+			index:    fi.Index,
+			typ:      fi.Field.Type,
+			deferred: inPointerStruct(fi),
+			wrapped:  reflect.TypeOf(typer.Scanner(ptr)) != reflect.TypeOf(ptr),
+		}
+		plan.deferred = plan.deferred || sf.deferred
+		plan.fields[i] = sf
+	}
+	return plan
+}
+
+// cachedScanPlan returns the scan plan for the type with given columns, which
+// is only built once for the same typer, mapper, type and columns with the
+// cache. It returns nil when the type is not a struct or a pointer to struct.
+func cachedScanPlan(plans *cache.LRU, typer adapter.Typer, mapper *reflectx.Mapper, typ reflect.Type, columns []string) *scanPlan {
+	typ = reflectx.Deref(typ)
+	if typ.Kind() != reflect.Struct || isScalar(typer, typ) {
+		return nil
+	}
+
+	if plans == nil || typer == nil {
+		return newScanPlan(typer, mapper, typ, columns)
+	}
+
+	key := scanPlanKey{
+		typer:   typer,
+		mapper:  mapper,
+		typ:     typ,
+		columns: columns,
+	}
+	if plan, ok := plans.Load(key); ok {
+		return plan.(*scanPlan)
+	}
+	plan := newScanPlan(typer, mapper, typ, columns)
+	plans.Set(key, plan)
+	return plan
+}
+
+// structScanner scans rows of a query into structs following the plan. It
+// reuses the scan destinations across rows, thus must not be shared between
+// queries.
+type structScanner struct {
+	plan    *scanPlan
+	values  []interface{}
+	discard interface{}
+}
+
+// newScanner returns a new structScanner following the plan.
+func (p *scanPlan) newScanner() *structScanner {
+	return &structScanner{
+		plan:   p,
+		values: make([]interface{}, len(p.fields)),
+	}
+}
+
+// scan scans the current row into the result that is a pointer to struct.
+func (s *structScanner) scan(typer adapter.Typer, rows adapter.Rows, result reflect.Value) error {
+	var deferred []*deferredField
+	if s.plan.deferred {
+		deferred = make([]*deferredField, 0, len(s.values))
+	}
+
+	structv := result.Elem()
+	for i, sf := range s.plan.fields {
+		switch {
+		case sf == nil:
+			s.values[i] = &s.discard
+		case sf.deferred:
+			var df *deferredField
+			df, s.values[i] = newDeferredField(typer, sf.typ, sf.index)
+			deferred = append(deferred, df)
+		case sf.wrapped:
+			s.values[i] = typer.Scanner(structv.FieldByIndex(sf.index).Addr().Interface())
+		default:
+			s.values[i] = structv.FieldByIndex(sf.index).Addr().Interface()
+		}
+	}
+
+	if err := rows.Scan(s.values...); err != nil {
+		return errors.Wrap(err, "scan")
+	}
+
+	for _, df := range deferred {
+		v, ok := df.value()
+		if !ok {
+			continue
+		}
+		reflectx.FieldByIndexes(result, df.index).Set(v)
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"unknwon.dev/norm/adapter"
+	"unknwon.dev/norm/internal/cache"
+	"unknwon.dev/norm/internal/reflectx"
+)
+
+// identityTyper is an adapter.Typer that does not wrap any type.
+type identityTyper struct{}
+
+func (identityTyper) Scanner(v interface{}) interface{} { return v }
+func (identityTyper) Valuer(v interface{}) interface{}  { return v }
+
+func (identityTyper) ScanType(adapter.ColumnType) reflect.Type { return nil }
+
+// benchRows is an adapter.Rows that returns the same row for given times.
+type benchRows struct {
+	columns []string
+	row     []interface{}
+	n       int
+}
+
+var _ adapter.Rows = (*benchRows)(nil)
+
+func (r *benchRows) Close() error               { return nil }
+func (r *benchRows) Columns() ([]string, error) { return r.columns, nil }
+func (r *benchRows) ColumnTypes() ([]adapter.ColumnType, error) {
+	return nil, errors.New("not implemented")
+}
+func (r *benchRows) Err() error { return nil }
+
+func (r *benchRows) Next() bool {
+	r.n--
+	return r.n >= 0
+}
+
+func (r *benchRows) NextResultSet() bool { return false }
+
+func (r *benchRows) Scan(dest ...interface{}) error {
+	for i := range dest {
+		switch d := dest[i].(type) {
+		case *int64:
+			*d = r.row[i].(int64)
+		case *string:
+			*d = r.row[i].(string)
+		case *time.Time:
+			*d = r.row[i].(time.Time)
+		case *interface{}:
+			*d = r.row[i]
+		default:
+			return errors.Errorf("unsupported destination type %T", d)
+		}
+	}
+	return nil
+}
+
+func BenchmarkFetchRows(b *testing.B) {
+	type user struct {
+		ID        int64     `db:"id"`
+		Name      string    `db:"name"`
+		Email     string    `db:"email"`
+		Bio       string    `db:"bio"`
+		CreatedAt time.Time `db:"created_at"`
+		UpdatedAt time.Time `db:"updated_at"`
+	}
+
+	const numRows = 1000
+	columns := []string{"id", "name", "email", "bio", "created_at", "updated_at", "extra"}
+	row := []interface{}{int64(1), "Joe", "joe@example.com", "Hi", time.Now(), time.Now(), "extra"}
+
+	ctx := context.Background()
+	adp := NewMockAdapter()
+	adp.TyperFunc.SetDefaultReturn(identityTyper{})
+	run := func(b *testing.B, plans *cache.LRU) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			rows := &benchRows{columns: columns, row: row, n: numRows}
+			var dest []*user
+			iter := &iterator{adapter: adp, cursor: rows, mapper: defaultMapper, scanPlans: plans}
+			if err := iter.fetchRows(ctx, &dest); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+	b.Run("cached plan", func(b *testing.B) { run(b, NewScanPlanCache()) })
+	b.Run("uncached plan", func(b *testing.B) { run(b, nil) })
+
+	b.Run("without scan plans", func(b *testing.B) {
+		b.ReportAllocs()
+		typ := reflect.TypeOf(&user{})
+		for i := 0; i < b.N; i++ {
+			rows := &benchRows{columns: columns, row: row, n: numRows}
+			var dest []*user
+			for rows.Next() {
+				item, err := scanResultWithoutPlan(identityTyper{}, defaultMapper, rows, typ, columns)
+				if err != nil {
+					b.Fatal(err)
+				}
+				dest = append(dest, item.Elem().Interface().(*user))
+			}
+		}
+	})
+}
+
+// scanResultWithoutPlan is how a row was scanned into a pointer to struct
+// before scan plans were introduced, i.e. the mapping of columns was resolved
+// for every row. It is only kept as the baseline of BenchmarkFetchRows.
+func scanResultWithoutPlan(typer adapter.Typer, mapper *reflectx.Mapper, rows adapter.Rows, typ reflect.Type, columns []string) (reflect.Value, error) {
+	result := reflect.New(typ)
+	target := result
+	if typ.Kind() == reflect.Ptr && typ.Elem().Kind() == reflect.Struct && !isScalar(typer, typ.Elem()) {
+		target = reflect.New(typ.Elem())
+		result.Elem().Set(target)
+	}
+	if isScalar(typer, target.Type().Elem()) {
+		return reflect.Value{}, errors.Errorf("unexpected scalar type %v", typ)
+	}
+
+	values := make([]interface{}, len(columns))
+	fieldMap := mapper.TypeMap(target.Type().Elem()).Names
+	var deferred []*deferredField
+	for i, k := range columns {
+		fi, ok := fieldMap[k]
+		if !ok {
+			values[i] = new(interface{})
+			continue
+		}
+
+		if inPointerStruct(fi) {
+			var df *deferredField
+			df, values[i] = newDeferredField(typer, fi.Field.Type, fi.Index)
+			deferred = append(deferred, df)
+			continue
+		}
+
+		f := reflectx.FieldByIndexes(target, fi.Index)
+		values[i] = typer.Scanner(f.Addr().Interface())
+	}
+
+	if err := rows.Scan(values...); err != nil {
+		return reflect.Value{}, errors.Wrap(err, "scan")
+	}
+
+	for _, df := range deferred {
+		v, ok := df.value()
+		if !ok {
+			continue
+		}
+		reflectx.FieldByIndexes(target, df.index).Set(v)
+	}
+	return result, nil
+}
+
+func TestCachedScanPlan(t *testing.T) {
+	typer := NewMockTyper()
+	typer.ScannerFunc.SetDefaultHook(func(v interface{}) interface{} {
+		if v, ok := v.(*[]int64); ok {
+			return &sql.NullString{String: fmt.Sprint(*v)}
+		}
+		return v
+	})
+
+	type owner struct {
+		Name string `db:"name"`
+	}
+	type team struct {
+		ID      int64   `db:"id"`
+		Members []int64 `db:"members"`
+		Owner   *owner  `db:"owner"`
+	}
+
+	plans := NewScanPlanCache()
+	columns := []string{"id", "members", "owner.name", "extra"}
+	plan := cachedScanPlan(plans, typer, defaultMapper, reflect.TypeOf(&team{}), columns)
+	require.NotNil(t, plan)
+	assert.True(t, plan.deferred)
+
+	got := make([]scanField, len(plan.fields))
+	for i, sf := range plan.fields {
+		if sf != nil {
+			got[i] = *sf
+		}
+	}
+	want := []scanField{
+		{index: []int{0}, typ: reflect.TypeOf(int64(0))},
+		{index: []int{1}, typ: reflect.TypeOf([]int64{}), wrapped: true},
+		{index: []int{2, 0}, typ: reflect.TypeOf(""), deferred: true},
+		{},
+	}
+	assert.Equal(t, want, got)
+
+	t.Run("cached", func(t *testing.T) {
+		assert.Same(t, plan, cachedScanPlan(plans, typer, defaultMapper, reflect.TypeOf(team{}), columns))
+		assert.NotSame(t, plan, cachedScanPlan(plans, typer, defaultMapper, reflect.TypeOf(team{}), columns[:2]))
+		assert.NotSame(t, plan, cachedScanPlan(plans, NewMockTyper(), defaultMapper, reflect.TypeOf(team{}), columns))
+		assert.NotSame(t, plan, cachedScanPlan(NewScanPlanCache(), typer, defaultMapper, reflect.TypeOf(team{}), columns))
+		assert.NotSame(t, plan, cachedScanPlan(nil, typer, defaultMapper, reflect.TypeOf(team{}), columns))
+	})
+
+	t.Run("not a struct", func(t *testing.T) {
+		assert.Nil(t, cachedScanPlan(plans, typer, defaultMapper, reflect.TypeOf(map[string]interface{}{}), columns))
+		assert.Nil(t, cachedScanPlan(plans, typer, defaultMapper, reflect.TypeOf(time.Time{}), columns))
+	})
+}
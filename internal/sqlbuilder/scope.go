@@ -0,0 +1,91 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"unknwon.dev/norm"
+	"unknwon.dev/norm/expr"
+)
+
+// scopeValue is the placeholder argument of the value of a scope, which is
+// resolved from the context when executing the query, so that queries are built
+// the same way regardless of the context, including subqueries.
+type scopeValue struct {
+	table string
+	scope *norm.Scope
+}
+
+func (v *scopeValue) String() string {
+	return fmt.Sprintf("<scope %s.%s>", v.table, v.scope.Column)
+}
+
+// scopesOf returns the scopes that apply to the table.
+func (b *sqlBuilder) scopesOf(table string) []*norm.Scope {
+	var scopes []*norm.Scope
+	for i := range b.options.Scopes {
+		for _, t := range b.options.Scopes[i].Tables {
+			if t == table {
+				scopes = append(scopes, &b.options.Scopes[i])
+				break
+			}
+		}
+	}
+	return scopes
+}
+
+// scopeConditions returns the conditions of scopes that apply to the table,
+// where columns are qualified by the ref when it is not empty.
+func (b *sqlBuilder) scopeConditions(table, ref string) []interface{} {
+	if len(b.options.Scopes) == 0 {
+		return nil
+	}
+
+	scopes := b.scopesOf(table)
+	conds := make([]interface{}, 0, len(scopes))
+	for _, s := range scopes {
+		column := s.Column
+		if ref != "" {
+			column = ref + "." + column
+		}
+		conds = append(conds, expr.Cond{column: expr.Eq(&scopeValue{table: table, scope: s})})
+	}
+	return conds
+}
+
+// resolveArguments returns the arguments with values of scopes resolved from
+// the context. It returns an error that wraps norm.ErrMissingScopeValue when
+// any value is missing.
+func (b *sqlBuilder) resolveArguments(ctx context.Context, args []interface{}) ([]interface{}, error) {
+	if len(b.options.Scopes) == 0 {
+		return args, nil
+	}
+
+	var resolved []interface{}
+	for i := range args {
+		v, ok := args[i].(*scopeValue)
+		if !ok {
+			continue
+		}
+
+		if resolved == nil {
+			resolved = make([]interface{}, len(args))
+			copy(resolved, args)
+		}
+		value, ok := v.scope.Value(ctx)
+		if !ok {
+			return nil, errors.Wrapf(norm.ErrMissingScopeValue, "column %q of table %q", v.scope.Column, v.table)
+		}
+		resolved[i] = value
+	}
+	if resolved == nil {
+		return args, nil
+	}
+	return resolved, nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"unknwon.dev/norm"
+	"unknwon.dev/norm/internal/exql"
+)
+
+type tenantKey struct{}
+
+func TestScopes(t *testing.T) {
+	ctx := context.WithValue(context.Background(), tenantKey{}, int64(7))
+
+	var gotQuery string
+	var gotArgs []interface{}
+	executor := NewMockExecutor()
+	executor.ExecFunc.SetDefaultHook(func(_ context.Context, stmt *exql.Statement, args ...interface{}) (sql.Result, error) {
+		q, err := stmt.Compile(defaultTemplate(t))
+		require.NoError(t, err)
+		gotQuery, gotArgs = exql.StripWhitespace(q), args
+		return nil, nil
+	})
+
+	typer := NewMockTyper()
+	typer.ValuerFunc.SetDefaultHook(func(v interface{}) interface{} {
+		return v
+	})
+
+	adapter := NewMockAdapter()
+	adapter.ExecutorFunc.SetDefaultReturn(executor)
+	adapter.TyperFunc.SetDefaultReturn(typer)
+	adapter.FormatSQLFunc.SetDefaultHook(func(sql string) string {
+		return exql.StripWhitespace(sql)
+	})
+
+	scope := norm.Scope{
+		Tables: []string{"projects", "issues"},
+		Column: "tenant_id",
+		Value: func(ctx context.Context) (interface{}, bool) {
+			id, ok := ctx.Value(tenantKey{}).(int64)
+			return id, ok
+		},
+	}
+	sqlb := New(adapter, defaultTemplate(t), Options{Scopes: []norm.Scope{scope}})
+	tenant := func(table string) *scopeValue {
+		return &scopeValue{table: table, scope: &sqlb.(*sqlBuilder).options.Scopes[0]}
+	}
+
+	t.Run("select", func(t *testing.T) {
+		sel := sqlb.SelectFrom("projects AS p").
+			Join("issues AS i").On("i.project_id = p.id").
+			Join("users").On("users.id = i.author_id").
+			Where("p.id = ?", 1)
+		assert.Equal(t, `SELECT * FROM "projects" AS "p" JOIN "issues" AS "i" ON (("i"."project_id" = "p"."id") AND "i"."tenant_id" = ?) JOIN "users" ON ("users"."id" = "i"."author_id") WHERE (p.id = ?) AND "p"."tenant_id" = ?`, sel.String())
+		assert.Equal(t, []interface{}{tenant("issues"), 1, tenant("projects")}, sel.Arguments())
+	})
+
+	t.Run("select using", func(t *testing.T) {
+		sel := sqlb.SelectFrom("users").Join("issues").Using("author_id")
+		assert.Equal(t, `SELECT * FROM "users" JOIN "issues" USING ("author_id") WHERE "issues"."tenant_id" = ?`, sel.String())
+		assert.Equal(t, []interface{}{tenant("issues")}, sel.Arguments())
+	})
+
+	t.Run("select left join using", func(t *testing.T) {
+		_, err := sqlb.SelectFrom("users").LeftJoin("issues").Using("author_id").(*selector).Compile()
+		assert.EqualError(t, err, `build: scope tables: cannot scope LEFT JOIN of table "issues" without an ON clause`)
+	})
+
+	t.Run("subquery", func(t *testing.T) {
+		sel := sqlb.SelectFrom("users").Where("id IN ?", sqlb.Select("author_id").From("issues"))
+		assert.Equal(t, `SELECT * FROM "users" WHERE id IN (SELECT "author_id" FROM "issues" WHERE "issues"."tenant_id" = ?)`, sel.String())
+		assert.Equal(t, []interface{}{tenant("issues")}, sel.Arguments())
+	})
+
+	t.Run("insert", func(t *testing.T) {
+		_, err := sqlb.InsertInto("projects").Columns("name").Values("a").Values("b").Exec(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, `INSERT INTO "projects" ("name", "tenant_id") VALUES (?, ?), (?, ?)`, gotQuery)
+		assert.Equal(t, []interface{}{"a", int64(7), "b", int64(7)}, gotArgs)
+	})
+
+	t.Run("insert with column", func(t *testing.T) {
+		_, err := sqlb.InsertInto("projects").Columns("name", "tenant_id").Values("a", 8).Exec(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, `INSERT INTO "projects" ("name", "tenant_id") VALUES (?, ?)`, gotQuery)
+		assert.Equal(t, []interface{}{"a", 8}, gotArgs)
+	})
+
+	t.Run("insert without columns", func(t *testing.T) {
+		_, err := sqlb.InsertInto("projects").Values("a").Exec(ctx)
+		assert.EqualError(t, err, `build query: scope column "tenant_id": columns are not specified`)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		_, err := sqlb.Update("projects").Set("name", "a").Where("id = ?", 1).Exec(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, `UPDATE "projects" SET "name" = ? WHERE (id = ?) AND "tenant_id" = ?`, gotQuery)
+		assert.Equal(t, []interface{}{"a", 1, int64(7)}, gotArgs)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		_, err := sqlb.DeleteFrom("issues").Where("id = ?", 1).Exec(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, `DELETE FROM "issues" WHERE (id = ?) AND "tenant_id" = ?`, gotQuery)
+		assert.Equal(t, []interface{}{1, int64(7)}, gotArgs)
+	})
+
+	t.Run("other table", func(t *testing.T) {
+		_, err := sqlb.DeleteFrom("users").Where("id = ?", 1).Exec(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, `DELETE FROM "users" WHERE id = ?`, gotQuery)
+	})
+
+	t.Run("missing value", func(t *testing.T) {
+		_, err := sqlb.DeleteFrom("issues").Where("id = ?", 1).Exec(context.Background())
+		assert.True(t, errors.Is(err, norm.ErrMissingScopeValue))
+		assert.EqualError(t, err, `resolve arguments: column "tenant_id" of table "issues": missing scope value`)
+
+		err = sqlb.SelectFrom("issues").All(context.Background(), &[]map[string]interface{}{})
+		assert.True(t, errors.Is(err, norm.ErrMissingScopeValue))
+	})
+}
@@ -51,6 +51,16 @@ func (sel *selector) Columns(columns ...interface{}) norm.Selector {
 	})
 }
 
+func (sel *selector) ColumnsOf(table string, dest interface{}) norm.Selector {
+	return sel.frame(func(sq *selectorQuery) error {
+		columns, err := structColumns(sel.Builder().mapper(), table, dest)
+		if err != nil {
+			return errors.Wrap(err, "ColumnsOf")
+		}
+		return errors.Wrap(sq.pushColumns(columns), "ColumnsOf")
+	})
+}
+
 func (sel *selector) From(tables ...interface{}) norm.Selector {
 	if len(tables) == 0 {
 		return sel
@@ -268,7 +278,7 @@ func (sel *selector) On(conds ...interface{}) norm.Selector {
 		}
 
 		lastJoin.On = exql.On(conds...)
-		sq.joinsArgs = append(sq.joinsArgs, condsArgs...)
+		sq.joinsArgs[joins-1] = append(sq.joinsArgs[joins-1], condsArgs...)
 		return nil
 	})
 }
@@ -298,7 +308,7 @@ func (sel *selector) Using(columns ...interface{}) norm.Selector {
 			using[i] = exql.Column(cs[i])
 		}
 		lastJoin.Using = exql.Using(using...)
-		sq.joinsArgs = append(sq.joinsArgs, args...)
+		sq.joinsArgs[joins-1] = append(sq.joinsArgs[joins-1], args...)
 		return nil
 	})
 }
@@ -330,18 +340,49 @@ func (sel *selector) Amend(fn func(query string) string) norm.Selector {
 	})
 }
 
+func (sel *selector) Strict() norm.Selector {
+	return sel.frame(func(sq *selectorQuery) error {
+		sq.strict = true
+		return nil
+	})
+}
+
+func (sel *selector) WithDeleted() norm.Selector {
+	return sel.frame(func(sq *selectorQuery) error {
+		sq.deletedScope = scopeWithDeleted
+		return nil
+	})
+}
+
+func (sel *selector) OnlyDeleted() norm.Selector {
+	return sel.frame(func(sq *selectorQuery) error {
+		sq.deletedScope = scopeOnlyDeleted
+		return nil
+	})
+}
+
 func (sel *selector) Iterate(ctx context.Context) norm.Iterator {
+	b := sel.Builder().withContext(ctx)
 	sq, err := sel.build()
 	if err != nil {
 		return &iterator{err: errors.Wrap(err, "build query")}
 	}
 
-	adapter := sel.Builder().Adapter
-	rows, err := adapter.Executor().Query(ctx, sq.statement(), sq.arguments()...) //nolint:rowserrcheck
+	args, err := b.resolveArguments(ctx, sq.arguments())
+	if err != nil {
+		return &iterator{err: errors.Wrap(err, "resolve arguments")}
+	}
+
+	adapter := b.Adapter
+	rows, err := adapter.Executor().Query(ctx, sq.statement(), args...) //nolint:rowserrcheck
 	return &iterator{
-		adapter: adapter,
-		cursor:  rows,
-		err:     errors.Wrap(err, "execute query"),
+		adapter:   adapter,
+		cursor:    rows,
+		mapper:    b.mapper(),
+		strict:    b.options.StrictMapping || sq.strict,
+		scanPlans: b.options.ScanPlans,
+		db:        b.options.DB,
+		err:       errors.Wrap(err, "execute query"),
 	}
 }
 
@@ -353,6 +394,13 @@ func (sel *selector) One(ctx context.Context, dest interface{}) error {
 	return sel.Iterate(ctx).One(ctx, dest)
 }
 
+func (sel *selector) Pluck(ctx context.Context, column interface{}, destSlice interface{}) error {
+	return sel.frame(func(sq *selectorQuery) error {
+		sq.columns, sq.columnsArgs = nil, nil
+		return errors.Wrap(sq.pushColumns([]interface{}{column}), "Pluck")
+	}).All(ctx, destSlice)
+}
+
 func (sel *selector) String() string {
 	q, err := sel.Compile()
 	if err != nil {
@@ -362,11 +410,17 @@ func (sel *selector) String() string {
 }
 
 func (sel *selector) build() (*selectorQuery, error) {
-	sq, err := immutable.FastForward(sel)
+	q, err := immutable.FastForward(sel)
 	if err != nil {
 		return nil, errors.Wrap(err, "construct *selectorQuery")
 	}
-	return sq.(*selectorQuery), nil
+
+	sq := q.(*selectorQuery)
+	err = sq.scope(sel.Builder())
+	if err != nil {
+		return nil, errors.Wrap(err, "scope tables")
+	}
+	return sq, nil
 }
 
 func (sel *selector) Arguments() []interface{} {
@@ -433,8 +487,13 @@ type selectorQuery struct {
 	columns     *exql.ColumnsFragment
 	columnsArgs []interface{}
 
-	joins     []*exql.JoinFragment
-	joinsArgs []interface{}
+	joins []*exql.JoinFragment
+	// joinsArgs is the list of arguments of each join.
+	joinsArgs [][]interface{}
+
+	strict bool
+
+	deletedScope deletedScope
 
 	amendFn func(string) string
 }
@@ -456,14 +515,11 @@ func flattenArguments(args ...[]interface{}) []interface{} {
 }
 
 func (sq *selectorQuery) arguments() []interface{} {
-	return flattenArguments(
-		sq.columnsArgs,
-		sq.tableArgs,
-		sq.joinsArgs,
-		sq.whereArgs,
-		sq.groupByArgs,
-		sq.orderByArgs,
-	)
+	args := make([][]interface{}, 0, 5+len(sq.joinsArgs))
+	args = append(args, sq.columnsArgs, sq.tableArgs)
+	args = append(args, sq.joinsArgs...)
+	args = append(args, sq.whereArgs, sq.groupByArgs, sq.orderByArgs)
+	return flattenArguments(args...)
 }
 
 func (sq *selectorQuery) pushColumns(exprs []interface{}) error {
@@ -500,8 +556,83 @@ func (sq *selectorQuery) and(t *exql.Template, conditions ...interface{}) error
 	return nil
 }
 
+// scope appends the conditions of scopes and of soft-deleted rows for tables in
+// the FROM and JOIN clauses. Soft-deleted rows of joined tables are excluded
+// unless WithDeleted is used. Conditions of joined tables are appended to their
+// ON clauses when possible, otherwise to the WHERE clause.
+func (sq *selectorQuery) scope(b *sqlBuilder) error {
+	if len(b.options.SoftDeleteTables) == 0 && len(b.options.Scopes) == 0 {
+		return nil
+	}
+
+	var conds []interface{}
+	if sq.table != nil {
+		for _, t := range sq.table.Tables {
+			name, alias, ok := t.NameAndAlias()
+			if !ok {
+				continue
+			}
+			if alias == "" {
+				alias = name
+			}
+
+			conds = append(conds, b.scopeConditions(name, alias)...)
+			if !b.softDeletable(name) {
+				continue
+			}
+			if cond := sq.deletedScope.condition(alias + "." + softDeleteColumn); cond != nil {
+				conds = append(conds, cond)
+			}
+		}
+	}
+
+	for i, j := range sq.joins {
+		if j.Table == nil {
+			continue
+		}
+		name, alias, ok := j.Table.NameAndAlias()
+		if !ok {
+			continue
+		}
+		if alias == "" {
+			alias = name
+		}
+
+		joinConds := b.scopeConditions(name, alias)
+		if b.softDeletable(name) && sq.deletedScope != scopeWithDeleted {
+			joinConds = append(joinConds, scopeNotDeleted.condition(alias+"."+softDeleteColumn))
+		}
+		if len(joinConds) == 0 {
+			continue
+		} else if j.On == nil {
+			// Conditions of an outer join can only be added to its ON clause, the
+			// WHERE clause would filter out rows that have no match.
+			switch j.Type {
+			case exql.LeftJoin, exql.RightJoin, exql.FullJoin:
+				return errors.Errorf("cannot scope %s JOIN of table %q without an ON clause", j.Type, name)
+			}
+			conds = append(conds, joinConds...)
+			continue
+		}
+
+		on, args, err := parseConditionExpressions(b.Template, joinConds)
+		if err != nil {
+			return errors.Wrap(err, "parse condition expressions")
+		}
+		j.On = exql.On(append([]exql.Fragment{exql.And(j.On.Conditions...)}, on...)...)
+		sq.joinsArgs[i] = append(sq.joinsArgs[i], args...)
+	}
+	if len(conds) == 0 {
+		return nil
+	}
+
+	sq.where = groupWhere(sq.where)
+	return sq.and(b.Template, conds...)
+}
+
 func (sq *selectorQuery) pushJoin(typ exql.JoinType, table interface{}) error {
 	sq.joins = append(sq.joins, exql.JoinOn(typ, table, nil))
+	sq.joinsArgs = append(sq.joinsArgs, nil)
 	return nil
 }
 
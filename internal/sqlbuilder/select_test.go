@@ -7,12 +7,16 @@ package sqlbuilder
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"testing"
+	"time"
 
 	mockrequire "github.com/derision-test/go-mockgen/testutil/require"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"unknwon.dev/norm"
+	normadapter "unknwon.dev/norm/adapter"
 	"unknwon.dev/norm/expr"
 	"unknwon.dev/norm/internal/exql"
 )
@@ -276,6 +280,57 @@ func TestSelector_Columns(t *testing.T) {
 	assert.Equal(t, want, sel.String())
 }
 
+func TestSelector_ColumnsOf(t *testing.T) {
+	adapter := NewMockAdapter()
+	adapter.FormatSQLFunc.SetDefaultHook(func(sql string) string {
+		return exql.StripWhitespace(sql)
+	})
+
+	type Publisher struct {
+		Name string `db:"name"`
+	}
+	type Author struct {
+		ID        int64      `db:"id"`
+		Name      string     `db:"name"`
+		Publisher *Publisher `db:"publisher"`
+	}
+	type Timestamps struct {
+		CreatedAt time.Time `db:"created_at"`
+	}
+	type Book struct {
+		ID       int64          `db:"id"`
+		Subtitle sql.NullString `db:"subtitle"`
+		Author   *Author        `db:"author,prefix=author_"`
+		Timestamps
+	}
+
+	tmpl := defaultTemplate(t)
+	sqlb := New(adapter, tmpl)
+
+	t.Run("qualified", func(t *testing.T) {
+		got := sqlb.Select().ColumnsOf("books", &Book{}).From("books").String()
+		want := `SELECT "books"."id" AS "id", "books"."subtitle" AS "subtitle", "author"."id" AS "author_id", "author"."name" AS "author_name", "books"."created_at" AS "created_at", "publisher"."name" AS "author_publisher.name" FROM "books"`
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("unqualified", func(t *testing.T) {
+		got := sqlb.Select().ColumnsOf("", Publisher{}).From("publishers").String()
+		want := `SELECT "name" FROM "publishers"`
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := sqlb.Select().ColumnsOf("books", nil).(*selector).Compile()
+		assert.Error(t, err)
+
+		_, err = sqlb.Select().ColumnsOf("books", "string").(*selector).Compile()
+		assert.Error(t, err)
+
+		_, err = sqlb.Select().ColumnsOf("books", struct{}{}).(*selector).Compile()
+		assert.Error(t, err)
+	})
+}
+
 func TestSelector_From(t *testing.T) {
 	adapter := NewMockAdapter()
 	adapter.FormatSQLFunc.SetDefaultHook(func(sql string) string {
@@ -666,3 +721,97 @@ func TestSelector_Iterate(t *testing.T) {
 	err = sqlb.Select().One(ctx, &dest)
 	assert.EqualError(t, err, sql.ErrNoRows.Error())
 }
+
+func TestSelector_Pluck(t *testing.T) {
+	ctx := context.Background()
+
+	cursor := NewMockCursor()
+	cursor.ColumnsFunc.SetDefaultReturn([]string{"id"}, nil)
+	cursor.NextFunc.PushReturn(true)
+	cursor.ScanFunc.PushHook(func(dest ...interface{}) error {
+		*dest[0].(*int64) = 1
+		return nil
+	})
+
+	var gotQuery string
+	executor := NewMockExecutor()
+	executor.QueryFunc.SetDefaultHook(func(_ context.Context, stmt *exql.Statement, _ ...interface{}) (normadapter.Rows, error) {
+		var err error
+		gotQuery, err = stmt.Compile(defaultTemplate(t))
+		require.NoError(t, err)
+		return cursor, nil
+	})
+
+	typer := NewMockTyper()
+	typer.ScannerFunc.SetDefaultHook(func(v interface{}) interface{} {
+		return v
+	})
+	typer.ValuerFunc.SetDefaultHook(func(v interface{}) interface{} {
+		return v
+	})
+
+	adapter := NewMockAdapter()
+	adapter.ExecutorFunc.SetDefaultReturn(executor)
+	adapter.TyperFunc.SetDefaultReturn(typer)
+
+	var ids []int64
+	err := New(adapter, defaultTemplate(t)).
+		Select("name").
+		From("users").
+		Pluck(ctx, "id", &ids)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1}, ids)
+	assert.Equal(t, `SELECT "id" FROM "users"`, exql.StripWhitespace(gotQuery))
+}
+
+func TestSelector_Strict(t *testing.T) {
+	ctx := context.Background()
+
+	newAdapter := func() *MockAdapter {
+		cursor := NewMockCursor()
+		cursor.ColumnsFunc.SetDefaultReturn([]string{"id", "nickname"}, nil)
+		cursor.NextFunc.PushReturn(true)
+
+		executor := NewMockExecutor()
+		executor.QueryFunc.SetDefaultReturn(cursor, nil)
+
+		typer := NewMockTyper()
+		typer.ScannerFunc.SetDefaultHook(func(v interface{}) interface{} {
+			return v
+		})
+		typer.ValuerFunc.SetDefaultHook(func(v interface{}) interface{} {
+			return v
+		})
+
+		adapter := NewMockAdapter()
+		adapter.ExecutorFunc.SetDefaultReturn(executor)
+		adapter.TyperFunc.SetDefaultReturn(typer)
+		return adapter
+	}
+
+	type user struct {
+		ID int64 `db:"id"`
+	}
+
+	t.Run("non-strict", func(t *testing.T) {
+		var dest user
+		err := New(newAdapter(), defaultTemplate(t)).SelectFrom("users").One(ctx, &dest)
+		assert.NoError(t, err)
+	})
+
+	t.Run("per query", func(t *testing.T) {
+		var dest user
+		err := New(newAdapter(), defaultTemplate(t)).SelectFrom("users").Strict().One(ctx, &dest)
+
+		var mappingErr *norm.MappingError
+		assert.True(t, errors.As(err, &mappingErr))
+	})
+
+	t.Run("builder options", func(t *testing.T) {
+		var dest user
+		err := New(newAdapter(), defaultTemplate(t), Options{StrictMapping: true}).SelectFrom("users").One(ctx, &dest)
+
+		var mappingErr *norm.MappingError
+		assert.True(t, errors.As(err, &mappingErr))
+	})
+}
@@ -0,0 +1,64 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"unknwon.dev/norm/expr"
+	"unknwon.dev/norm/internal/exql"
+)
+
+// softDeleteColumn is the column of soft-deletable tables that holds the time
+// of deletion, rows with NULL values are not deleted.
+const softDeleteColumn = "deleted_at"
+
+// deletedScope is the scope of soft-deleted rows that a query applies to.
+type deletedScope int
+
+const (
+	// scopeNotDeleted only applies to rows that are not deleted.
+	scopeNotDeleted deletedScope = iota
+	// scopeWithDeleted applies to both deleted and not deleted rows.
+	scopeWithDeleted
+	// scopeOnlyDeleted only applies to rows that are deleted.
+	scopeOnlyDeleted
+)
+
+// condition returns the condition on the given soft-delete column for the
+// scope, or nil if no condition is needed.
+func (s deletedScope) condition(column string) interface{} {
+	switch s {
+	case scopeNotDeleted:
+		return expr.Cond{column: expr.IsNull()}
+	case scopeOnlyDeleted:
+		return expr.Cond{column: expr.IsNotNull()}
+	default:
+		return nil
+	}
+}
+
+// softDeletable returns true if the table is registered as soft-deletable.
+func (b *sqlBuilder) softDeletable(table string) bool {
+	for _, t := range b.options.SoftDeleteTables {
+		if t == table {
+			return true
+		}
+	}
+	return false
+}
+
+// tableName returns the name of the table without the alias.
+func tableName(table string) string {
+	name, _, _ := exql.Table(table).NameAndAlias()
+	return name
+}
+
+// groupWhere groups the conditions of the WHERE clause so that conditions
+// appended later cannot be taken over by them, e.g. "a OR b".
+func groupWhere(where *exql.WhereFragment) *exql.WhereFragment {
+	if where == nil || len(where.Conditions) == 0 {
+		return where
+	}
+	return exql.Where(exql.And(where.Conditions...))
+}
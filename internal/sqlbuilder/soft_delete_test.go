@@ -0,0 +1,186 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"unknwon.dev/norm"
+	"unknwon.dev/norm/internal/exql"
+)
+
+func TestSoftDelete(t *testing.T) {
+	now := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	typer := NewMockTyper()
+	typer.ValuerFunc.SetDefaultHook(func(v interface{}) interface{} {
+		return v
+	})
+
+	adapter := NewMockAdapter()
+	adapter.TyperFunc.SetDefaultReturn(typer)
+	adapter.FormatSQLFunc.SetDefaultHook(func(sql string) string {
+		return exql.StripWhitespace(sql)
+	})
+
+	sqlb := New(adapter, defaultTemplate(t), Options{
+		NowFunc:          func() time.Time { return now },
+		SoftDeleteTables: []string{"users"},
+	})
+
+	tests := []struct {
+		name  string
+		query interface {
+			String() string
+			Arguments() []interface{}
+		}
+		wantSQL  string
+		wantArgs []interface{}
+	}{
+		{
+			name:    "select",
+			query:   sqlb.SelectFrom("users"),
+			wantSQL: `SELECT * FROM "users" WHERE "users"."deleted_at" IS NULL`,
+		},
+		{
+			name:     "select with conditions",
+			query:    sqlb.SelectFrom("users").Where("id = ? OR name = ?", 1, "alice"),
+			wantSQL:  `SELECT * FROM "users" WHERE (id = ? OR name = ?) AND "users"."deleted_at" IS NULL`,
+			wantArgs: []interface{}{1, "alice"},
+		},
+		{
+			name:    "select with alias",
+			query:   sqlb.SelectFrom("users AS u").Join("emails").On("emails.user_id = u.id"),
+			wantSQL: `SELECT * FROM "users" AS "u" JOIN "emails" ON ("emails"."user_id" = "u"."id") WHERE "u"."deleted_at" IS NULL`,
+		},
+		{
+			name:    "select joined",
+			query:   sqlb.SelectFrom("emails").Join("users AS u").On("u.id = emails.user_id"),
+			wantSQL: `SELECT * FROM "emails" JOIN "users" AS "u" ON (("u"."id" = "emails"."user_id") AND "u"."deleted_at" IS NULL)`,
+		},
+		{
+			name:    "select joined using",
+			query:   sqlb.SelectFrom("emails").Join("users").Using("id"),
+			wantSQL: `SELECT * FROM "emails" JOIN "users" USING ("id") WHERE "users"."deleted_at" IS NULL`,
+		},
+		{
+			name:    "select joined with deleted",
+			query:   sqlb.SelectFrom("emails").Join("users").On("users.id = emails.user_id").WithDeleted(),
+			wantSQL: `SELECT * FROM "emails" JOIN "users" ON ("users"."id" = "emails"."user_id")`,
+		},
+		{
+			name:    "select joined only deleted",
+			query:   sqlb.SelectFrom("users").Join("users AS m").On("m.id = users.manager_id").OnlyDeleted(),
+			wantSQL: `SELECT * FROM "users" JOIN "users" AS "m" ON (("m"."id" = "users"."manager_id") AND "m"."deleted_at" IS NULL) WHERE "users"."deleted_at" IS NOT NULL`,
+		},
+		{
+			name:    "select with deleted",
+			query:   sqlb.SelectFrom("users").WithDeleted(),
+			wantSQL: `SELECT * FROM "users"`,
+		},
+		{
+			name:    "select only deleted",
+			query:   sqlb.SelectFrom("users").OnlyDeleted(),
+			wantSQL: `SELECT * FROM "users" WHERE "users"."deleted_at" IS NOT NULL`,
+		},
+		{
+			name:    "select other table",
+			query:   sqlb.SelectFrom("emails"),
+			wantSQL: `SELECT * FROM "emails"`,
+		},
+
+		{
+			name:     "update",
+			query:    sqlb.Update("users").Set("name", "alice").Where("id = ?", 1),
+			wantSQL:  `UPDATE "users" SET "name" = ? WHERE (id = ?) AND "deleted_at" IS NULL`,
+			wantArgs: []interface{}{"alice", 1},
+		},
+		{
+			name:     "update with deleted",
+			query:    sqlb.Update("users").Set("name", "alice").WithDeleted(),
+			wantSQL:  `UPDATE "users" SET "name" = ?`,
+			wantArgs: []interface{}{"alice"},
+		},
+		{
+			name:     "update only deleted",
+			query:    sqlb.Update("users").Set("deleted_at", nil).OnlyDeleted(),
+			wantSQL:  `UPDATE "users" SET "deleted_at" = ? WHERE "deleted_at" IS NOT NULL`,
+			wantArgs: []interface{}{nil},
+		},
+
+		{
+			name:     "delete",
+			query:    sqlb.DeleteFrom("users").Where("id = ?", 1),
+			wantSQL:  `UPDATE "users" SET "deleted_at" = ? WHERE (id = ?) AND "deleted_at" IS NULL`,
+			wantArgs: []interface{}{now, 1},
+		},
+		{
+			name:     "delete with returning",
+			query:    sqlb.DeleteFrom("users").Where("id = ?", 1).Returning("id"),
+			wantSQL:  `UPDATE "users" SET "deleted_at" = ? WHERE (id = ?) AND "deleted_at" IS NULL RETURNING "id"`,
+			wantArgs: []interface{}{now, 1},
+		},
+		{
+			name:     "hard delete",
+			query:    sqlb.DeleteFrom("users").Where("id = ?", 1).HardDelete(),
+			wantSQL:  `DELETE FROM "users" WHERE (id = ?) AND "deleted_at" IS NULL`,
+			wantArgs: []interface{}{1},
+		},
+		{
+			name:    "hard delete only deleted",
+			query:   sqlb.DeleteFrom("users").HardDelete().OnlyDeleted(),
+			wantSQL: `DELETE FROM "users" WHERE "deleted_at" IS NOT NULL`,
+		},
+		{
+			name:     "delete other table",
+			query:    sqlb.DeleteFrom("emails").Where("id = ?", 1),
+			wantSQL:  `DELETE FROM "emails" WHERE id = ?`,
+			wantArgs: []interface{}{1},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.wantSQL, test.query.String())
+			assert.Equal(t, test.wantArgs, test.query.Arguments())
+		})
+	}
+}
+
+func TestSoftDelete_NotRegistered(t *testing.T) {
+	adapter := NewMockAdapter()
+	adapter.FormatSQLFunc.SetDefaultHook(func(sql string) string {
+		return exql.StripWhitespace(sql)
+	})
+
+	var sqlb norm.SQL = New(adapter, defaultTemplate(t))
+	assert.Equal(t, `DELETE FROM "users"`, sqlb.DeleteFrom("users").String())
+	assert.Equal(t, `SELECT * FROM "users"`, sqlb.SelectFrom("users").OnlyDeleted().String())
+}
+
+func TestSoftDelete_OuterJoin(t *testing.T) {
+	adapter := NewMockAdapter()
+	adapter.FormatSQLFunc.SetDefaultHook(func(sql string) string {
+		return exql.StripWhitespace(sql)
+	})
+	sqlb := New(adapter, defaultTemplate(t), Options{SoftDeleteTables: []string{"users"}})
+
+	t.Run("on", func(t *testing.T) {
+		sel := sqlb.SelectFrom("emails").LeftJoin("users").On("users.id = emails.user_id")
+		assert.Equal(t, `SELECT * FROM "emails" LEFT JOIN "users" ON (("users"."id" = "emails"."user_id") AND "users"."deleted_at" IS NULL)`, sel.String())
+	})
+
+	t.Run("using", func(t *testing.T) {
+		_, err := sqlb.SelectFrom("emails").LeftJoin("users").Using("id").(*selector).Compile()
+		assert.EqualError(t, err, `build: scope tables: cannot scope LEFT JOIN of table "users" without an ON clause`)
+	})
+
+	t.Run("using with deleted", func(t *testing.T) {
+		sel := sqlb.SelectFrom("emails").LeftJoin("users").Using("id").WithDeleted()
+		assert.Equal(t, `SELECT * FROM "emails" LEFT JOIN "users" USING ("id")`, sel.String())
+	})
+}
@@ -0,0 +1,58 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"unknwon.dev/norm/internal/reflectx"
+)
+
+const (
+	// optionAutoCreateTime is the tag option of fields that are set to the
+	// current time when inserting, unless they are already set.
+	optionAutoCreateTime = "autocreatetime"
+	// optionAutoUpdateTime is the tag option of fields that are set to the
+	// current time when inserting and updating.
+	optionAutoUpdateTime = "autoupdatetime"
+)
+
+// setTimestamps sets the fields of the model that have automatic timestamp tag
+// options to the given time, where creating indicates whether the model is
+// being inserted. Only models that are pointers to struct are changed.
+func setTimestamps(mapper *reflectx.Mapper, model interface{}, now time.Time, creating bool) error {
+	v := reflect.ValueOf(model)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return nil
+	}
+
+	structv := v.Elem()
+	for _, fi := range structFields(mapper, structv.Type()) {
+		_, autoCreate := fi.Options[optionAutoCreateTime]
+		_, autoUpdate := fi.Options[optionAutoUpdateTime]
+		if !autoUpdate && !(autoCreate && creating) {
+			continue
+		}
+
+		f := reflectx.FieldByIndexes(structv, fi.Index)
+		if !autoUpdate && !f.IsZero() {
+			continue
+		}
+
+		switch f.Type() {
+		case timeType:
+			f.Set(reflect.ValueOf(now))
+		case reflect.PtrTo(timeType):
+			t := now
+			f.Set(reflect.ValueOf(&t))
+		default:
+			return errors.Errorf("field %q: automatic timestamps expect time.Time or *time.Time but got %v", fi.Field.Name, f.Type())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"unknwon.dev/norm/internal/exql"
+)
+
+func TestTimestamps(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	earlier := now.Add(-time.Hour)
+
+	type post struct {
+		Title     string     `db:"title"`
+		CreatedAt time.Time  `db:"created_at,autocreatetime"`
+		UpdatedAt *time.Time `db:"updated_at,autoupdatetime"`
+	}
+
+	newSQL := func(gotArgs *[]interface{}) *sqlBuilder {
+		executor := NewMockExecutor()
+		executor.ExecFunc.SetDefaultHook(func(_ context.Context, _ *exql.Statement, args ...interface{}) (sql.Result, error) {
+			*gotArgs = args
+			return nil, nil
+		})
+
+		typer := NewMockTyper()
+		adapter := NewMockAdapter()
+		adapter.ExecutorFunc.SetDefaultReturn(executor)
+		adapter.TyperFunc.SetDefaultReturn(typer)
+		return New(adapter, defaultTemplate(t), Options{NowFunc: func() time.Time { return now }}).(*sqlBuilder)
+	}
+
+	t.Run("insert", func(t *testing.T) {
+		var args []interface{}
+		p := &post{Title: "Hello"}
+		_, err := newSQL(&args).InsertInto("posts").Values(p).Exec(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, &post{Title: "Hello", CreatedAt: now, UpdatedAt: &now}, p)
+		assert.Equal(t, []interface{}{"Hello", now, &now}, args)
+	})
+
+	t.Run("insert keeps set creation time", func(t *testing.T) {
+		var args []interface{}
+		p := &post{Title: "Hello", CreatedAt: earlier}
+		_, err := newSQL(&args).InsertInto("posts").Values(p).Exec(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{"Hello", earlier, &now}, args)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		var args []interface{}
+		p := &post{Title: "Hello", UpdatedAt: &earlier}
+		_, err := newSQL(&args).Update("posts").Set(p).Where("id = ?", 1).Exec(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, &post{Title: "Hello", UpdatedAt: &now}, p)
+		assert.Equal(t, []interface{}{"Hello", &now, 1}, args)
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		type comment struct {
+			CreatedAt int64 `db:"created_at,autocreatetime"`
+		}
+
+		var args []interface{}
+		_, err := newSQL(&args).InsertInto("comments").Values(&comment{}).Exec(ctx)
+		assert.EqualError(t, err, `set timestamps of *sqlbuilder.comment: field "CreatedAt": automatic timestamps expect time.Time or *time.Time but got int64`)
+	})
+}
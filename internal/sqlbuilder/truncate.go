@@ -0,0 +1,148 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pkg/errors"
+
+	"unknwon.dev/norm"
+	"unknwon.dev/norm/internal/exql"
+	"unknwon.dev/norm/internal/immutable"
+)
+
+var _ norm.Truncater = (*truncater)(nil)
+
+type truncater struct {
+	builder *sqlBuilder
+
+	prev *truncater
+	fn   func(*truncaterQuery) error
+}
+
+func (tr *truncater) frame(fn func(*truncaterQuery) error) *truncater {
+	return &truncater{
+		prev: tr,
+		fn:   fn,
+	}
+}
+
+func (tr *truncater) Builder() *sqlBuilder {
+	if tr.prev == nil {
+		return tr.builder
+	}
+	return tr.prev.Builder()
+}
+
+func (tr *truncater) Tables(tables ...string) *truncater {
+	return tr.frame(func(tq *truncaterQuery) error {
+		tq.tables = tables
+		return nil
+	})
+}
+
+func (tr *truncater) RestartIdentity() norm.Truncater {
+	return tr.frame(func(tq *truncaterQuery) error {
+		tq.restartIdentity = true
+		return nil
+	})
+}
+
+func (tr *truncater) Cascade() norm.Truncater {
+	return tr.frame(func(tq *truncaterQuery) error {
+		tq.cascade = true
+		return nil
+	})
+}
+
+func (tr *truncater) Amend(fn func(query string) string) norm.Truncater {
+	return tr.frame(func(tq *truncaterQuery) error {
+		tq.amendFn = fn
+		return nil
+	})
+}
+
+func (tr *truncater) Exec(ctx context.Context) (sql.Result, error) {
+	tq, err := tr.build()
+	if err != nil {
+		return nil, errors.Wrap(err, "build query")
+	}
+
+	result, err := tr.Builder().withContext(ctx).Executor().Exec(ctx, tq.statement())
+	if err != nil {
+		return nil, errors.Wrap(err, "execute query")
+	}
+	return result, nil
+}
+
+func (tr *truncater) String() string {
+	q, err := tr.Compile()
+	if err != nil {
+		panic("unable to compile TRUNCATE query: " + err.Error())
+	}
+	return tr.Builder().FormatSQL(q)
+}
+
+func (tr *truncater) build() (*truncaterQuery, error) {
+	tq, err := immutable.FastForward(tr)
+	if err != nil {
+		return nil, errors.Wrap(err, "construct *truncaterQuery")
+	}
+
+	q := tq.(*truncaterQuery)
+	if len(q.tables) == 0 {
+		return nil, errors.New("no table is specified")
+	}
+	return q, nil
+}
+
+func (tr *truncater) Compile() (string, error) {
+	tq, err := tr.build()
+	if err != nil {
+		return "", errors.Wrap(err, "build")
+	}
+	return tq.statement().Compile(tr.Builder().Template)
+}
+
+var _ immutable.Immutable = (*truncater)(nil)
+
+func (tr *truncater) Prev() immutable.Immutable {
+	if tr == nil {
+		return nil
+	}
+	return tr.prev
+}
+
+func (tr *truncater) Fn(in interface{}) error {
+	if tr.fn == nil {
+		return nil
+	}
+	return tr.fn(in.(*truncaterQuery))
+}
+
+func (tr *truncater) Base() interface{} {
+	return &truncaterQuery{}
+}
+
+type truncaterQuery struct {
+	tables          []string
+	restartIdentity bool
+	cascade         bool
+
+	amendFn func(string) string
+}
+
+func (tq *truncaterQuery) statement() *exql.Statement {
+	stmt := &exql.Statement{
+		Type:            exql.StatementTruncate,
+		Table:           tablesFragment(tq.tables),
+		RestartIdentity: tq.restartIdentity,
+		Cascade:         tq.cascade,
+	}
+	stmt.SetAmend(tq.amendFn)
+	return stmt
+}
@@ -0,0 +1,59 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	mockrequire "github.com/derision-test/go-mockgen/testutil/require"
+	"github.com/stretchr/testify/assert"
+
+	"unknwon.dev/norm/internal/exql"
+)
+
+func TestTruncater(t *testing.T) {
+	adapter := NewMockAdapter()
+	adapter.FormatSQLFunc.SetDefaultHook(func(sql string) string {
+		return exql.StripWhitespace(sql)
+	})
+
+	tmpl := defaultTemplate(t)
+	sql := New(adapter, tmpl)
+
+	t.Run("normal", func(t *testing.T) {
+		got := sql.Truncate("users").String()
+		assert.Equal(t, `TRUNCATE TABLE "users"`, got)
+	})
+
+	t.Run("restart identity cascade", func(t *testing.T) {
+		got := sql.Truncate("users", "emails").RestartIdentity().Cascade().String()
+		assert.Equal(t, `TRUNCATE TABLE "users", "emails" RESTART IDENTITY CASCADE`, got)
+	})
+
+	t.Run("no table", func(t *testing.T) {
+		_, err := sql.Truncate().Exec(context.Background())
+		assert.Error(t, err)
+	})
+}
+
+func TestTruncater_Exec(t *testing.T) {
+	ctx := context.Background()
+
+	executor := NewMockExecutor()
+	executor.ExecFunc.SetDefaultHook(func(_ context.Context, stmt *exql.Statement, _ ...interface{}) (sql.Result, error) {
+		assert.Equal(t, exql.StatementTruncate, stmt.Type)
+		return nil, nil
+	})
+
+	adapter := NewMockAdapter()
+	adapter.ExecutorFunc.SetDefaultReturn(executor)
+
+	tmpl := defaultTemplate(t)
+	_, err := New(adapter, tmpl).Truncate("users").Exec(ctx)
+	assert.NoError(t, err)
+	mockrequire.Called(t, executor.ExecFunc)
+}
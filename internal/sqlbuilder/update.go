@@ -7,6 +7,8 @@ package sqlbuilder
 
 import (
 	"context"
+	"database/sql"
+	"reflect"
 
 	"github.com/pkg/errors"
 
@@ -50,39 +52,69 @@ func (upd *updater) Set(kvs ...interface{}) norm.Updater {
 	if len(kvs) == 0 {
 		return upd
 	}
+	if len(kvs) == 1 && isStructValue(upd.Builder().Typer(), kvs[0]) {
+		v := kvs[0]
+		return upd.frame(func(uq *updaterQuery) error {
+			// Values are taken when building the query, so that changes made by hooks
+			// are included.
+			columns, values := structValues(upd.Builder().mapper(), v, optionAutoCreateTime)
+			lock, err := newOptimisticLock(upd.Builder().mapper(), v, columns, values)
+			if err != nil {
+				return errors.Wrapf(err, "Set: %T", v)
+			}
+
+			kvs := make([]interface{}, 0, 2*len(columns))
+			for i := range columns {
+				if lock != nil && columns[i] == lock.field.Path {
+					kvs = append(kvs, columns[i], lock.next.Interface())
+					continue
+				}
+				kvs = append(kvs, columns[i], values[i])
+			}
+			uq.models = append(uq.models, v)
+			if lock != nil {
+				uq.locks = append(uq.locks, lock)
+			}
+			return upd.set(uq, kvs)
+		})
+	}
 	return upd.frame(func(uq *updaterQuery) error {
 		if len(kvs)%2 != 0 {
 			return errors.Errorf("Set: odd number of key-value pairs: %d", len(kvs))
 		}
+		return upd.set(uq, kvs)
+	})
+}
 
-		cvs := make([]*exql.ColumnValueFragment, 0, len(kvs)/2)
-		args := make([]interface{}, 0, len(cvs))
-		for i := 0; i < len(kvs); i += 2 {
-			cv := exql.ColumnValue(kvs[i], upd.Builder().Layout(exql.LayoutAssignmentOperator), nil)
-			switch v := kvs[i+1].(type) {
-			case *expr.RawExpr:
-				cv.Value = exql.Raw(v.Raw())
-				args = append(args, v.Arguments()...)
-			case *expr.FuncExpr:
-				fnName, fnArgs, err := expandFuncExpr(v)
-				if err != nil {
-					return errors.Wrap(err, "Set: expand *expr.FuncExpr")
-				}
-				cv.Value = exql.Raw(fnName)
-				args = append(args, fnArgs...)
-			case exql.Fragment:
-				cv.Value = v
-			default:
-				cv.Value = exql.Raw("?")
-				args = append(args, v)
+// set appends the pairs of key names and values to the SET clause.
+func (upd *updater) set(uq *updaterQuery, kvs []interface{}) error {
+	cvs := make([]*exql.ColumnValueFragment, 0, len(kvs)/2)
+	args := make([]interface{}, 0, len(cvs))
+	for i := 0; i < len(kvs); i += 2 {
+		cv := exql.ColumnValue(kvs[i], upd.Builder().Layout(exql.LayoutAssignmentOperator), nil)
+		switch v := kvs[i+1].(type) {
+		case *expr.RawExpr:
+			cv.Value = exql.Raw(v.Raw())
+			args = append(args, v.Arguments()...)
+		case *expr.FuncExpr:
+			fnName, fnArgs, err := expandFuncExpr(v)
+			if err != nil {
+				return errors.Wrap(err, "Set: expand *expr.FuncExpr")
 			}
-			cvs = append(cvs, cv)
+			cv.Value = exql.Raw(fnName)
+			args = append(args, fnArgs...)
+		case exql.Fragment:
+			cv.Value = v
+		default:
+			cv.Value = exql.Raw("?")
+			args = append(args, v)
 		}
+		cvs = append(cvs, cv)
+	}
 
-		uq.columnValues = append(uq.columnValues, cvs...)
-		uq.columnValuesArgs = append(uq.columnValuesArgs, args...)
-		return nil
-	})
+	uq.columnValues = append(uq.columnValues, cvs...)
+	uq.columnValuesArgs = append(uq.columnValuesArgs, args...)
+	return nil
 }
 
 func (upd *updater) Where(conds ...interface{}) norm.Updater {
@@ -125,27 +157,143 @@ func (upd *updater) Amend(fn func(string) string) norm.Updater {
 	})
 }
 
-func (upd *updater) Iterate(ctx context.Context) norm.Iterator {
-	iq, err := upd.build()
+func (upd *updater) WithDeleted() norm.Updater {
+	return upd.frame(func(uq *updaterQuery) error {
+		uq.deletedScope = scopeWithDeleted
+		return nil
+	})
+}
+
+func (upd *updater) OnlyDeleted() norm.Updater {
+	return upd.frame(func(uq *updaterQuery) error {
+		uq.deletedScope = scopeOnlyDeleted
+		return nil
+	})
+}
+
+// prepare builds the query after setting automatic timestamps and calling the
+// BeforeUpdate hooks of models.
+func (upd *updater) prepare(ctx context.Context) (*updaterQuery, error) {
+	b := upd.Builder().withContext(ctx)
+	uq, err := upd.build()
+	if err != nil {
+		return nil, errors.Wrap(err, "build query")
+	}
+	if len(uq.models) == 0 {
+		return uq, nil
+	}
+
+	now := b.now()
+	for _, m := range uq.models {
+		if err = setTimestamps(b.mapper(), m, now, false); err != nil {
+			return nil, errors.Wrapf(err, "set timestamps of %T", m)
+		}
+	}
+	if err = beforeUpdate(ctx, b.options.DB, uq.models); err != nil {
+		return nil, err
+	}
+	// Rebuild the query to include changes made by hooks
+	uq, err = upd.build()
+	if err != nil {
+		return nil, errors.Wrap(err, "build query")
+	}
+	return uq, nil
+}
+
+func (upd *updater) Exec(ctx context.Context) (sql.Result, error) {
+	b := upd.Builder().withContext(ctx)
+	uq, err := upd.prepare(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	args, err := b.resolveArguments(ctx, uq.arguments())
+	if err != nil {
+		return nil, errors.Wrap(err, "resolve arguments")
+	}
+
+	result, err := b.Executor().Exec(ctx, uq.statement(), args...)
 	if err != nil {
-		return &iterator{err: errors.Wrap(err, "build query")}
+		return nil, errors.Wrap(err, "execute query")
+	}
+	if len(uq.locks) > 0 {
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return nil, errors.Wrap(err, "get affected rows")
+		}
+		if affected == 0 {
+			return nil, uq.locks[0].stale()
+		}
+		uq.advanceLocks()
 	}
 
-	adapter := upd.Builder().Adapter
-	rows, err := adapter.Executor().Query(ctx, iq.statement(), upd.Arguments()...) //nolint:rowserrcheck
+	err = afterUpdate(ctx, b.options.DB, uq.models)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// iterate executes the query without calling the AfterUpdate hooks.
+func (upd *updater) iterate(ctx context.Context) (*iterator, *updaterQuery) {
+	b := upd.Builder().withContext(ctx)
+	uq, err := upd.prepare(ctx)
+	if err != nil {
+		return &iterator{err: err}, nil
+	}
+
+	args, err := b.resolveArguments(ctx, uq.arguments())
+	if err != nil {
+		return &iterator{err: errors.Wrap(err, "resolve arguments")}, nil
+	}
+
+	adapter := b.Adapter
+	rows, err := adapter.Executor().Query(ctx, uq.statement(), args...) //nolint:rowserrcheck
 	return &iterator{
-		adapter: adapter,
-		cursor:  rows,
-		err:     errors.Wrap(err, "execute query"),
+		adapter:   adapter,
+		cursor:    rows,
+		mapper:    b.mapper(),
+		strict:    b.options.StrictMapping,
+		scanPlans: b.options.ScanPlans,
+		db:        b.options.DB,
+		err:       errors.Wrap(err, "execute query"),
+	}, uq
+}
+
+func (upd *updater) Iterate(ctx context.Context) norm.Iterator {
+	iter, uq := upd.iterate(ctx)
+	if uq != nil {
+		iter.closed = func() error {
+			return afterUpdate(ctx, iter.db, uq.models)
+		}
 	}
+	return iter
 }
 
 func (upd *updater) All(ctx context.Context, destSlice interface{}) error {
-	return upd.Iterate(ctx).All(ctx, destSlice)
+	iter, uq := upd.iterate(ctx)
+	if err := iter.All(ctx, destSlice); err != nil {
+		return err
+	}
+	if len(uq.locks) > 0 {
+		if reflect.Indirect(reflect.ValueOf(destSlice)).Len() == 0 {
+			return uq.locks[0].stale()
+		}
+		uq.advanceLocks()
+	}
+	return afterUpdate(ctx, iter.db, uq.models)
 }
 
 func (upd *updater) One(ctx context.Context, dest interface{}) error {
-	return upd.Iterate(ctx).One(ctx, dest)
+	iter, uq := upd.iterate(ctx)
+	if err := iter.One(ctx, dest); err != nil {
+		if uq != nil && len(uq.locks) > 0 && errors.Is(err, sql.ErrNoRows) {
+			return uq.locks[0].stale()
+		}
+		return err
+	}
+	uq.advanceLocks()
+	return afterUpdate(ctx, iter.db, uq.models)
 }
 
 func (upd *updater) String() string {
@@ -157,11 +305,31 @@ func (upd *updater) String() string {
 }
 
 func (upd *updater) build() (*updaterQuery, error) {
-	uq, err := immutable.FastForward(upd)
+	q, err := immutable.FastForward(upd)
 	if err != nil {
 		return nil, errors.Wrap(err, "construct *updaterQuery")
 	}
-	return uq.(*updaterQuery), nil
+
+	// The conditions of scopes, soft-deleted rows and versions of optimistic
+	// locks are appended last so that they are not replaced by Where.
+	uq := q.(*updaterQuery)
+	table := tableName(uq.table)
+	conds := upd.Builder().scopeConditions(table, "")
+	if upd.Builder().softDeletable(table) {
+		if cond := uq.deletedScope.condition(softDeleteColumn); cond != nil {
+			conds = append(conds, cond)
+		}
+	}
+	for _, lock := range uq.locks {
+		conds = append(conds, expr.Cond{lock.field.Path: expr.Eq(lock.version)})
+	}
+	if len(conds) > 0 {
+		uq.where = groupWhere(uq.where)
+		if err = uq.and(upd.Builder().Template, conds...); err != nil {
+			return nil, errors.Wrap(err, "append implicit conditions")
+		}
+	}
+	return uq, nil
 }
 
 func (upd *updater) Arguments() []interface{} {
@@ -212,12 +380,18 @@ type updaterQuery struct {
 
 	columnValues     []*exql.ColumnValueFragment
 	columnValuesArgs []interface{}
+	// models is the list of structs that are passed to Set.
+	models []interface{}
+	// locks is the list of optimistic locks of models.
+	locks []*optimisticLock
 
 	where     *exql.WhereFragment
 	whereArgs []interface{}
 
 	returning *exql.ReturningFragment
 
+	deletedScope deletedScope
+
 	amendFn func(string) string
 }
 
@@ -228,6 +402,13 @@ func (uq *updaterQuery) arguments() []interface{} {
 	)
 }
 
+// advanceLocks sets the version fields of models to their next versions.
+func (uq *updaterQuery) advanceLocks() {
+	for _, lock := range uq.locks {
+		lock.advance()
+	}
+}
+
 func (uq *updaterQuery) and(t *exql.Template, conditions ...interface{}) error {
 	conds, condsArgs, err := parseConditionExpressions(t, conditions)
 	if err != nil {
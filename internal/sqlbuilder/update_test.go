@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"unknwon.dev/norm"
+	"unknwon.dev/norm/adapter"
 	"unknwon.dev/norm/expr"
 	"unknwon.dev/norm/internal/exql"
 )
@@ -136,6 +137,35 @@ func TestUpdater(t *testing.T) {
 	}
 }
 
+func TestUpdater_Struct(t *testing.T) {
+	typer := NewMockTyper()
+	typer.ValuerFunc.SetDefaultHook(func(v interface{}) interface{} {
+		return v
+	})
+
+	adapter := NewMockAdapter()
+	adapter.TyperFunc.SetDefaultReturn(typer)
+	adapter.FormatSQLFunc.SetDefaultHook(func(sql string) string {
+		return exql.StripWhitespace(sql)
+	})
+
+	type user struct {
+		ID        int64 `db:"-"`
+		FirstName string
+		Age       int
+	}
+
+	tmpl := defaultTemplate(t)
+	sql := New(adapter, tmpl, Options{Mapper: NewMapper(norm.MappingOptions{NameFunc: norm.SnakeCase})})
+	updater := sql.
+		Update("users").
+		Set(&user{ID: 1, FirstName: "alice", Age: 9}).
+		Set("updated_at", expr.Raw("NOW()")).
+		Where("id = ?", 1)
+	assert.Equal(t, `UPDATE "users" SET "first_name" = ?, "age" = ?, "updated_at" = NOW() WHERE id = ?`, updater.String())
+	assert.Equal(t, []interface{}{"alice", 9, 1}, updater.Arguments())
+}
+
 func TestUpdater_Amend(t *testing.T) {
 	adapter := NewMockAdapter()
 	adapter.FormatSQLFunc.SetDefaultHook(func(sql string) string {
@@ -167,8 +197,12 @@ func TestUpdater_Iterate(t *testing.T) {
 		return nil
 	})
 
+	var gotArgs []interface{}
 	executor := NewMockExecutor()
-	executor.QueryFunc.SetDefaultReturn(cursor, nil)
+	executor.QueryFunc.SetDefaultHook(func(_ context.Context, _ *exql.Statement, args ...interface{}) (adapter.Rows, error) {
+		gotArgs = args
+		return cursor, nil
+	})
 
 	typer := NewMockTyper()
 	typer.ValuerFunc.SetDefaultHook(func(v interface{}) interface{} {
@@ -186,10 +220,35 @@ func TestUpdater_Iterate(t *testing.T) {
 	sqlb := New(adapter, tmpl)
 
 	dest := make([]map[string]interface{}, 0)
-	err := sqlb.Update("users").All(ctx, &dest)
+	err := sqlb.Update("users").Set("name", "alice").Where("id = ?", 1).All(ctx, &dest)
 	assert.NoError(t, err)
 	mockrequire.Called(t, cursor.ScanFunc)
 
+	// Arguments are converted by the executor, not the builder.
+	assert.Equal(t, []interface{}{"alice", 1}, gotArgs)
+	mockrequire.NotCalled(t, typer.ValuerFunc)
+
 	err = sqlb.Update("users").One(ctx, &dest)
 	assert.EqualError(t, err, sql.ErrNoRows.Error())
 }
+
+func TestUpdater_Exec(t *testing.T) {
+	ctx := context.Background()
+
+	executor := NewMockExecutor()
+	executor.ExecFunc.SetDefaultHook(func(_ context.Context, stmt *exql.Statement, args ...interface{}) (sql.Result, error) {
+		got, err := stmt.Compile(defaultTemplate(t))
+		assert.NoError(t, err)
+		assert.Equal(t, `UPDATE "users" SET "name" = ? WHERE id = ?`, exql.StripWhitespace(got))
+		assert.Equal(t, []interface{}{"alice", 1}, args)
+		return nil, nil
+	})
+
+	adapter := NewMockAdapter()
+	adapter.ExecutorFunc.SetDefaultReturn(executor)
+
+	tmpl := defaultTemplate(t)
+	_, err := New(adapter, tmpl).Update("users").Set("name", "alice").Where("id = ?", 1).Exec(ctx)
+	assert.NoError(t, err)
+	mockrequire.Called(t, executor.ExecFunc)
+}
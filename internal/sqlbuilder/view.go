@@ -0,0 +1,312 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pkg/errors"
+
+	"unknwon.dev/norm"
+	"unknwon.dev/norm/expr"
+	"unknwon.dev/norm/internal/exql"
+	"unknwon.dev/norm/internal/immutable"
+)
+
+var _ norm.ViewCreator = (*viewCreator)(nil)
+
+type viewCreator struct {
+	builder *sqlBuilder
+
+	prev *viewCreator
+	fn   func(*viewCreatorQuery) error
+}
+
+func (vc *viewCreator) frame(fn func(*viewCreatorQuery) error) *viewCreator {
+	return &viewCreator{
+		prev: vc,
+		fn:   fn,
+	}
+}
+
+func (vc *viewCreator) Builder() *sqlBuilder {
+	if vc.prev == nil {
+		return vc.builder
+	}
+	return vc.prev.Builder()
+}
+
+func (vc *viewCreator) Name(name string) *viewCreator {
+	return vc.frame(func(vq *viewCreatorQuery) error {
+		vq.name = name
+		return nil
+	})
+}
+
+func (vc *viewCreator) Materialized() *viewCreator {
+	return vc.frame(func(vq *viewCreatorQuery) error {
+		vq.materialized = true
+		return nil
+	})
+}
+
+func (vc *viewCreator) OrReplace() norm.ViewCreator {
+	return vc.frame(func(vq *viewCreatorQuery) error {
+		vq.orReplace = true
+		return nil
+	})
+}
+
+func (vc *viewCreator) IfNotExists() norm.ViewCreator {
+	return vc.frame(func(vq *viewCreatorQuery) error {
+		vq.ifNotExists = true
+		return nil
+	})
+}
+
+func (vc *viewCreator) As(query interface{}) norm.ViewCreator {
+	return vc.frame(func(vq *viewCreatorQuery) error {
+		switch v := query.(type) {
+		case string:
+			vq.query = exql.Raw(v)
+		case *expr.RawExpr:
+			if len(v.Arguments()) > 0 {
+				return errors.New("As: bind parameters are not supported in *expr.RawExpr")
+			}
+			vq.query = exql.Raw(v.Raw())
+		case compilable:
+			q, err := v.Compile()
+			if err != nil {
+				return errors.Wrap(err, "As: compile")
+			} else if len(v.Arguments()) > 0 {
+				return errors.New("As: bind parameters are not supported")
+			}
+			vq.query = exql.Raw(q)
+		default:
+			return errors.Errorf("As: unsupported type %T", v)
+		}
+		return nil
+	})
+}
+
+func (vc *viewCreator) Amend(fn func(query string) string) norm.ViewCreator {
+	return vc.frame(func(vq *viewCreatorQuery) error {
+		vq.amendFn = fn
+		return nil
+	})
+}
+
+func (vc *viewCreator) Exec(ctx context.Context) (sql.Result, error) {
+	vq, err := vc.build()
+	if err != nil {
+		return nil, errors.Wrap(err, "build query")
+	}
+
+	result, err := vc.Builder().withContext(ctx).Executor().Exec(ctx, vq.statement())
+	if err != nil {
+		return nil, errors.Wrap(err, "execute query")
+	}
+	return result, nil
+}
+
+func (vc *viewCreator) String() string {
+	q, err := vc.Compile()
+	if err != nil {
+		panic("unable to compile CREATE VIEW query: " + err.Error())
+	}
+	return vc.Builder().FormatSQL(q)
+}
+
+func (vc *viewCreator) build() (*viewCreatorQuery, error) {
+	vq, err := immutable.FastForward(vc)
+	if err != nil {
+		return nil, errors.Wrap(err, "construct *viewCreatorQuery")
+	}
+
+	q := vq.(*viewCreatorQuery)
+	if q.query == nil {
+		return nil, errors.New("no query is specified")
+	} else if q.materialized && q.orReplace {
+		return nil, errors.New("OrReplace: not applicable to materialized views")
+	} else if !q.materialized && q.ifNotExists {
+		return nil, errors.New("IfNotExists: only applicable to materialized views")
+	}
+	return q, nil
+}
+
+func (vc *viewCreator) Compile() (string, error) {
+	vq, err := vc.build()
+	if err != nil {
+		return "", errors.Wrap(err, "build")
+	}
+	return vq.statement().Compile(vc.Builder().Template)
+}
+
+var _ immutable.Immutable = (*viewCreator)(nil)
+
+func (vc *viewCreator) Prev() immutable.Immutable {
+	if vc == nil {
+		return nil
+	}
+	return vc.prev
+}
+
+func (vc *viewCreator) Fn(in interface{}) error {
+	if vc.fn == nil {
+		return nil
+	}
+	return vc.fn(in.(*viewCreatorQuery))
+}
+
+func (vc *viewCreator) Base() interface{} {
+	return &viewCreatorQuery{}
+}
+
+type viewCreatorQuery struct {
+	name         string
+	query        exql.Fragment
+	materialized bool
+	orReplace    bool
+	ifNotExists  bool
+
+	amendFn func(string) string
+}
+
+func (vq *viewCreatorQuery) statement() *exql.Statement {
+	stmt := &exql.Statement{
+		Type:         exql.StatementCreateView,
+		Table:        exql.Table(vq.name),
+		Query:        vq.query,
+		Materialized: vq.materialized,
+		OrReplace:    vq.orReplace,
+		IfNotExists:  vq.ifNotExists,
+	}
+	stmt.SetAmend(vq.amendFn)
+	return stmt
+}
+
+var _ norm.MaterializedViewRefresher = (*materializedViewRefresher)(nil)
+
+type materializedViewRefresher struct {
+	builder *sqlBuilder
+
+	prev *materializedViewRefresher
+	fn   func(*materializedViewRefresherQuery) error
+}
+
+func (vr *materializedViewRefresher) frame(fn func(*materializedViewRefresherQuery) error) *materializedViewRefresher {
+	return &materializedViewRefresher{
+		prev: vr,
+		fn:   fn,
+	}
+}
+
+func (vr *materializedViewRefresher) Builder() *sqlBuilder {
+	if vr.prev == nil {
+		return vr.builder
+	}
+	return vr.prev.Builder()
+}
+
+func (vr *materializedViewRefresher) Name(name string) *materializedViewRefresher {
+	return vr.frame(func(vq *materializedViewRefresherQuery) error {
+		vq.name = name
+		return nil
+	})
+}
+
+func (vr *materializedViewRefresher) Concurrently() norm.MaterializedViewRefresher {
+	return vr.frame(func(vq *materializedViewRefresherQuery) error {
+		vq.concurrently = true
+		return nil
+	})
+}
+
+func (vr *materializedViewRefresher) Amend(fn func(query string) string) norm.MaterializedViewRefresher {
+	return vr.frame(func(vq *materializedViewRefresherQuery) error {
+		vq.amendFn = fn
+		return nil
+	})
+}
+
+func (vr *materializedViewRefresher) Exec(ctx context.Context) (sql.Result, error) {
+	b := vr.Builder().withContext(ctx)
+	vq, err := vr.build()
+	if err != nil {
+		return nil, errors.Wrap(err, "build query")
+	}
+
+	if vq.concurrently && b.inTransaction() {
+		return nil, errConcurrentlyInTransaction
+	}
+
+	result, err := b.Executor().Exec(ctx, vq.statement())
+	if err != nil {
+		return nil, errors.Wrap(err, "execute query")
+	}
+	return result, nil
+}
+
+func (vr *materializedViewRefresher) String() string {
+	q, err := vr.Compile()
+	if err != nil {
+		panic("unable to compile REFRESH MATERIALIZED VIEW query: " + err.Error())
+	}
+	return vr.Builder().FormatSQL(q)
+}
+
+func (vr *materializedViewRefresher) build() (*materializedViewRefresherQuery, error) {
+	vq, err := immutable.FastForward(vr)
+	if err != nil {
+		return nil, errors.Wrap(err, "construct *materializedViewRefresherQuery")
+	}
+	return vq.(*materializedViewRefresherQuery), nil
+}
+
+func (vr *materializedViewRefresher) Compile() (string, error) {
+	vq, err := vr.build()
+	if err != nil {
+		return "", errors.Wrap(err, "build")
+	}
+	return vq.statement().Compile(vr.Builder().Template)
+}
+
+var _ immutable.Immutable = (*materializedViewRefresher)(nil)
+
+func (vr *materializedViewRefresher) Prev() immutable.Immutable {
+	if vr == nil {
+		return nil
+	}
+	return vr.prev
+}
+
+func (vr *materializedViewRefresher) Fn(in interface{}) error {
+	if vr.fn == nil {
+		return nil
+	}
+	return vr.fn(in.(*materializedViewRefresherQuery))
+}
+
+func (vr *materializedViewRefresher) Base() interface{} {
+	return &materializedViewRefresherQuery{}
+}
+
+type materializedViewRefresherQuery struct {
+	name         string
+	concurrently bool
+
+	amendFn func(string) string
+}
+
+func (vq *materializedViewRefresherQuery) statement() *exql.Statement {
+	stmt := &exql.Statement{
+		Type:         exql.StatementRefreshMaterializedView,
+		Table:        exql.Table(vq.name),
+		Concurrently: vq.concurrently,
+	}
+	stmt.SetAmend(vq.amendFn)
+	return stmt
+}
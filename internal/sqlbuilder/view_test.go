@@ -0,0 +1,172 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	mockrequire "github.com/derision-test/go-mockgen/testutil/require"
+	"github.com/stretchr/testify/assert"
+
+	"unknwon.dev/norm"
+	"unknwon.dev/norm/expr"
+	"unknwon.dev/norm/internal/exql"
+)
+
+func TestViewCreator(t *testing.T) {
+	adapter := NewMockAdapter()
+	adapter.FormatSQLFunc.SetDefaultHook(func(sql string) string {
+		return exql.StripWhitespace(sql)
+	})
+
+	tmpl := defaultTemplate(t)
+	sql := New(adapter, tmpl)
+	tests := []struct {
+		name      string
+		creator   norm.ViewCreator
+		wantQuery string
+	}{
+		{
+			name:      "string",
+			creator:   sql.CreateView("active_users").As(`SELECT * FROM users WHERE active`),
+			wantQuery: `CREATE VIEW "active_users" AS SELECT * FROM users WHERE active`,
+		},
+		{
+			name: "selector",
+			creator: sql.CreateView("active_users").
+				OrReplace().
+				As(sql.SelectFrom("users").Where("deleted_at IS NULL")),
+			wantQuery: `CREATE OR REPLACE VIEW "active_users" AS SELECT * FROM "users" WHERE deleted_at IS NULL`,
+		},
+		{
+			name: "materialized",
+			creator: sql.CreateMaterializedView("user_stats").
+				IfNotExists().
+				As(expr.Raw(`SELECT COUNT(*) FROM users`)),
+			wantQuery: `CREATE MATERIALIZED VIEW IF NOT EXISTS "user_stats" AS SELECT COUNT(*) FROM users`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.wantQuery, test.creator.String())
+		})
+	}
+}
+
+func TestViewCreator_Errors(t *testing.T) {
+	ctx := context.Background()
+	typer := NewMockTyper()
+	typer.ValuerFunc.SetDefaultHook(func(v interface{}) interface{} {
+		return v
+	})
+
+	adapter := NewMockAdapter()
+	adapter.TyperFunc.SetDefaultReturn(typer)
+	tmpl := defaultTemplate(t)
+	sql := New(adapter, tmpl)
+
+	tests := []struct {
+		name    string
+		creator norm.ViewCreator
+	}{
+		{
+			name:    "no query",
+			creator: sql.CreateView("active_users"),
+		},
+		{
+			name:    "bind parameters",
+			creator: sql.CreateView("active_users").As(sql.SelectFrom("users").Where("active = ?", true)),
+		},
+		{
+			name:    "materialized or replace",
+			creator: sql.CreateMaterializedView("user_stats").OrReplace().As(`SELECT 1`),
+		},
+		{
+			name:    "if not exists",
+			creator: sql.CreateView("active_users").IfNotExists().As(`SELECT 1`),
+		},
+		{
+			name:    "unsupported type",
+			creator: sql.CreateView("active_users").As(1),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := test.creator.Exec(ctx)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestViewCreator_Exec(t *testing.T) {
+	ctx := context.Background()
+
+	executor := NewMockExecutor()
+	executor.ExecFunc.SetDefaultHook(func(_ context.Context, stmt *exql.Statement, _ ...interface{}) (sql.Result, error) {
+		assert.Equal(t, exql.StatementCreateView, stmt.Type)
+		assert.True(t, stmt.Materialized)
+		return nil, nil
+	})
+
+	adapter := NewMockAdapter()
+	adapter.ExecutorFunc.SetDefaultReturn(executor)
+
+	tmpl := defaultTemplate(t)
+	_, err := New(adapter, tmpl).CreateMaterializedView("user_stats").As(`SELECT 1`).Exec(ctx)
+	assert.NoError(t, err)
+	mockrequire.Called(t, executor.ExecFunc)
+}
+
+func TestMaterializedViewRefresher(t *testing.T) {
+	adapter := NewMockAdapter()
+	adapter.FormatSQLFunc.SetDefaultHook(func(sql string) string {
+		return exql.StripWhitespace(sql)
+	})
+
+	tmpl := defaultTemplate(t)
+	sql := New(adapter, tmpl)
+
+	t.Run("normal", func(t *testing.T) {
+		got := sql.RefreshMaterializedView("user_stats").String()
+		assert.Equal(t, `REFRESH MATERIALIZED VIEW "user_stats"`, got)
+	})
+
+	t.Run("concurrently", func(t *testing.T) {
+		got := sql.RefreshMaterializedView("user_stats").Concurrently().String()
+		assert.Equal(t, `REFRESH MATERIALIZED VIEW CONCURRENTLY "user_stats"`, got)
+	})
+}
+
+func TestMaterializedViewRefresher_Exec(t *testing.T) {
+	ctx := context.Background()
+	tmpl := defaultTemplate(t)
+
+	t.Run("normal", func(t *testing.T) {
+		executor := NewMockExecutor()
+		executor.ExecFunc.SetDefaultHook(func(_ context.Context, stmt *exql.Statement, _ ...interface{}) (sql.Result, error) {
+			assert.Equal(t, exql.StatementRefreshMaterializedView, stmt.Type)
+			return nil, nil
+		})
+
+		adapter := NewMockAdapter()
+		adapter.ExecutorFunc.SetDefaultReturn(executor)
+
+		_, err := New(adapter, tmpl).RefreshMaterializedView("user_stats").Concurrently().Exec(ctx)
+		assert.NoError(t, err)
+		mockrequire.Called(t, executor.ExecFunc)
+	})
+
+	t.Run("concurrently in transaction", func(t *testing.T) {
+		executor := NewMockExecutor()
+		adapter := NewMockAdapter()
+		adapter.ExecutorFunc.SetDefaultReturn(&mockTxExecutor{MockExecutor: executor})
+
+		_, err := New(adapter, tmpl).RefreshMaterializedView("user_stats").Concurrently().Exec(ctx)
+		assert.Equal(t, errConcurrentlyInTransaction, err)
+		mockrequire.NotCalled(t, executor.ExecFunc)
+	})
+}
@@ -0,0 +1,102 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package norm
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// MappingOptions contains options for mapping struct fields to columns, which
+// are used consistently by scanning query results, Selector.ColumnsOf, and
+// struct values of Inserter.Values and Updater.Set.
+type MappingOptions struct {
+	// TagName is the name of the struct field tag to look up column names and tag
+	// options. Default is "db".
+	TagName string
+	// NameFunc converts the Go field name to the column name for fields without a
+	// column name in their tags, e.g. SnakeCase. Default is to use the field name
+	// as-is.
+	NameFunc func(field string) string
+}
+
+// SnakeCase converts the Go field name to snake_case, e.g. "UserID" becomes
+// "user_id", "HTMLBody" becomes "html_body" and "TeamIDs" becomes "team_ids".
+func SnakeCase(name string) string {
+	runes := []rune(name)
+	isLower := func(i int) bool {
+		return i < len(runes) && unicode.IsLower(runes[i])
+	}
+
+	var b strings.Builder
+	b.Grow(len(name) + 4)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// Start a new word at a lower-to-upper transition, or at the last upper
+			// letter of an initialism that is followed by a lower letter, except for
+			// plural initialisms like "IDs".
+			newWord := i > 0 && runes[i-1] != '_' &&
+				(!unicode.IsUpper(runes[i-1]) ||
+					isLower(i+1) && !(runes[i+1] == 's' && !isLower(i+2)))
+			if newWord {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// MappingError is returned when the columns of query results do not match the
+// fields of the destination struct. Use errors.As to inspect the details:
+//
+//   var mappingErr *norm.MappingError
+//   if errors.As(err, &mappingErr) {
+//       ...
+//   }
+type MappingError struct {
+	// Type is the type of the destination struct.
+	Type reflect.Type
+	// UnmappedColumns is the list of columns that have no matching field. It is
+	// only reported in the strict mapping mode.
+	UnmappedColumns []string
+	// MissingFields is the list of paths of required fields (i.e. with the
+	// "required" tag option) that have no matching column.
+	MissingFields []string
+}
+
+func (e *MappingError) Error() string {
+	quote := func(names []string) string {
+		quoted := make([]string, len(names))
+		for i := range names {
+			quoted[i] = strconv.Quote(names[i])
+		}
+		return strings.Join(quoted, ", ")
+	}
+
+	var reasons []string
+	if len(e.UnmappedColumns) > 0 {
+		reasons = append(reasons, "unmapped columns "+quote(e.UnmappedColumns))
+	}
+	if len(e.MissingFields) > 0 {
+		reasons = append(reasons, "missing columns for required fields "+quote(e.MissingFields))
+	}
+	return fmt.Sprintf("map results to %v: %s", e.Type, strings.Join(reasons, "; "))
+}
+
+// ErrStaleObject is returned by Updater.Set with a struct that has a version
+// field (i.e. with the "optimisticlock" tag option) when no row is updated,
+// which means the row has been changed or deleted since the version was read.
+// Use errors.Is to check:
+//
+//   if errors.Is(err, norm.ErrStaleObject) {
+//       ...
+//   }
+var ErrStaleObject = errors.New("stale object")
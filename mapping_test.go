@@ -0,0 +1,34 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package norm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnakeCase(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "ID", want: "id"},
+		{name: "Name", want: "name"},
+		{name: "UserID", want: "user_id"},
+		{name: "HTMLBody", want: "html_body"},
+		{name: "TeamIDs", want: "team_ids"},
+		{name: "IDsList", want: "ids_list"},
+		{name: "CreatedAt", want: "created_at"},
+		{name: "Address2", want: "address2"},
+		{name: "already_snake", want: "already_snake"},
+		{name: "Snake_Case", want: "snake_case"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, SnakeCase(test.name))
+		})
+	}
+}
@@ -0,0 +1,455 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package migrate provides versioned schema migrations for the norm.DB.
+package migrate
+
+import (
+	"context"
+	"hash/fnv"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"unknwon.dev/norm"
+	"unknwon.dev/norm/expr"
+	"unknwon.dev/norm/internal/exql"
+)
+
+// ErrChecksumMismatch is returned when the checksum of an applied migration
+// differs from the one it was applied with.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// DefaultTable is the default name of the table to track applied migrations.
+const DefaultTable = "schema_migrations"
+
+// Options contains options for creating a Migrator.
+type Options struct {
+	// Table is the name of the table to track applied migrations. Default is
+	// DefaultTable.
+	Table string
+	// LockKey is the key of the PostgreSQL advisory lock to serialize concurrent
+	// migration runners. Default is derived from the Table.
+	LockKey int64
+}
+
+// Migrator runs versioned schema migrations against a database.
+type Migrator struct {
+	db         norm.DB
+	migrations []Migration
+	table      string
+	lockKey    int64
+}
+
+// New returns a new Migrator with the given migrations. Every migration runs
+// within its own transaction.
+func New(db norm.DB, migrations []Migration, opts ...Options) (*Migrator, error) {
+	var opt Options
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.Table == "" {
+		opt.Table = DefaultTable
+	}
+	if opt.LockKey == 0 {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte("norm/migrate:" + opt.Table))
+		opt.LockKey = int64(h.Sum64())
+	}
+
+	sorted := make([]Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Version < sorted[j].Version
+	})
+	for i, m := range sorted {
+		if m.Version <= 0 {
+			return nil, errors.Errorf("invalid version %d of %q", m.Version, m.Name)
+		} else if m.Up == nil {
+			return nil, errors.Errorf("missing up migration for version %d", m.Version)
+		} else if i > 0 && sorted[i-1].Version == m.Version {
+			return nil, errors.Errorf("duplicated version %d", m.Version)
+		}
+	}
+
+	return &Migrator{
+		db:         db,
+		migrations: sorted,
+		table:      opt.Table,
+		lockKey:    opt.LockKey,
+	}, nil
+}
+
+// Status is the status of a migration.
+type Status struct {
+	// Version is the version of the migration.
+	Version int64
+	// Name is the name of the migration.
+	Name string
+	// Applied indicates whether the migration has been applied.
+	Applied bool
+	// AppliedAt is the time when the migration was applied.
+	AppliedAt time.Time
+	// Drifted indicates whether the checksum of the migration differs from the
+	// one it was applied with.
+	Drifted bool
+	// Unknown indicates whether the migration has been applied but no longer
+	// exists in the list of migrations.
+	Unknown bool
+}
+
+// record is a row in the table of applied migrations.
+type record struct {
+	Version   int64     `db:"version"`
+	Name      string    `db:"name"`
+	Checksum  string    `db:"checksum"`
+	AppliedAt time.Time `db:"applied_at"`
+}
+
+// Up applies all pending migrations.
+func (m *Migrator) Up(ctx context.Context) error {
+	records, err := m.prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range planUp(m.migrations, records, -1) {
+		if err = m.apply(ctx, migration); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down reverts the last n applied migrations, where n must be positive.
+func (m *Migrator) Down(ctx context.Context, n int) error {
+	if n < 1 {
+		return errors.Errorf("invalid number of migrations to revert: %d", n)
+	}
+
+	records, err := m.prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	migrations, err := planDown(m.migrations, records, n)
+	if err != nil {
+		return err
+	}
+	for _, migration := range migrations {
+		if err = m.revert(ctx, migration); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// To applies or reverts migrations to make the given version the latest
+// applied one. Use version 0 to revert all migrations.
+func (m *Migrator) To(ctx context.Context, version int64) error {
+	if version != 0 && m.find(version) == nil {
+		return errors.Errorf("unknown version %d", version)
+	}
+
+	records, err := m.prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	var n int
+	for _, r := range records {
+		if r.Version > version {
+			n++
+		}
+	}
+	reverts, err := planDown(m.migrations, records, n)
+	if err != nil {
+		return err
+	}
+	for _, migration := range reverts {
+		if err = m.revert(ctx, migration); err != nil {
+			return err
+		}
+	}
+
+	for _, migration := range planUp(m.migrations, records, version) {
+		if err = m.apply(ctx, migration); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Status returns the status of all migrations, including applied ones that no
+// longer exist in the list of migrations. It has no side effects, all
+// migrations are pending when the table of applied migrations does not exist.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	exists, err := m.exists(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []record
+	if exists {
+		records, err = m.applied(ctx, m.db)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return status(m.migrations, records), nil
+}
+
+// prepare initializes the table of applied migrations and returns the applied
+// ones after verifying their checksums.
+func (m *Migrator) prepare(ctx context.Context) ([]record, error) {
+	if err := m.init(ctx); err != nil {
+		return nil, err
+	}
+
+	records, err := m.applied(ctx, m.db)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = verify(m.migrations, records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// init creates the table of applied migrations if it does not exist.
+func (m *Migrator) init(ctx context.Context) error {
+	err := m.db.Transaction(ctx, func(tx norm.DB) error {
+		if err := m.lock(ctx, tx); err != nil {
+			return err
+		}
+
+		_, err := tx.CreateTable(m.table).
+			IfNotExists().
+			Columns(
+				norm.ColumnDefinition{Name: "version", Type: "BIGINT", Constraints: []string{"PRIMARY KEY"}},
+				norm.ColumnDefinition{Name: "name", Type: "TEXT", NotNull: true},
+				norm.ColumnDefinition{Name: "checksum", Type: "TEXT", NotNull: true},
+				norm.ColumnDefinition{Name: "applied_at", Type: "TIMESTAMPTZ", NotNull: true, Default: expr.Func("NOW")},
+			).
+			Exec(ctx)
+		return err
+	})
+	return errors.Wrap(err, "create migrations table")
+}
+
+// exists returns true if the table of applied migrations exists, where the
+// name is resolved with the search path unless it is schema-qualified.
+func (m *Migrator) exists(ctx context.Context) (bool, error) {
+	rows, err := m.db.Adapter().Executor().Query(ctx, exql.RawSQL("SELECT to_regclass(?) IS NOT NULL"), m.table)
+	if err != nil {
+		return false, errors.Wrap(err, "check migrations table")
+	}
+	defer func() { _ = rows.Close() }()
+
+	var exists bool
+	if rows.Next() {
+		if err = rows.Scan(&exists); err != nil {
+			return false, errors.Wrap(err, "scan")
+		}
+	}
+	return exists, errors.Wrap(rows.Err(), "check migrations table")
+}
+
+// lock acquires the advisory lock that is held until the end of the
+// transaction.
+func (m *Migrator) lock(ctx context.Context, tx norm.DB) error {
+	_, err := tx.Adapter().Executor().Exec(ctx, exql.RawSQL("SELECT pg_advisory_xact_lock(?)"), m.lockKey)
+	return errors.Wrap(err, "acquire advisory lock")
+}
+
+// applied returns all applied migrations in the ascending order of versions.
+func (m *Migrator) applied(ctx context.Context, db norm.DB) ([]record, error) {
+	var records []record
+	err := db.Select("version", "name", "checksum", "applied_at").
+		From(m.table).
+		OrderBy("version").
+		All(ctx, &records)
+	if err != nil {
+		return nil, errors.Wrap(err, "list applied migrations")
+	}
+	return records, nil
+}
+
+// isApplied returns true if the migration with given version has been applied.
+func (m *Migrator) isApplied(ctx context.Context, tx norm.DB, version int64) (bool, error) {
+	var records []record
+	err := tx.Select("version").
+		From(m.table).
+		Where("version = ?", version).
+		All(ctx, &records)
+	if err != nil {
+		return false, errors.Wrap(err, "check applied")
+	}
+	return len(records) > 0, nil
+}
+
+// apply applies the migration within a transaction. It is a no-op if the
+// migration has been applied by a concurrent runner.
+func (m *Migrator) apply(ctx context.Context, migration Migration) error {
+	err := m.db.Transaction(ctx, func(tx norm.DB) error {
+		if err := m.lock(ctx, tx); err != nil {
+			return err
+		}
+
+		applied, err := m.isApplied(ctx, tx, migration.Version)
+		if err != nil {
+			return err
+		} else if applied {
+			return nil
+		}
+
+		if err = migration.Up(ctx, tx); err != nil {
+			return err
+		}
+
+		_, err = tx.InsertInto(m.table).
+			Columns("version", "name", "checksum").
+			Values(migration.Version, migration.Name, migration.checksum).
+			Exec(ctx)
+		return errors.Wrap(err, "record migration")
+	})
+	return errors.Wrapf(err, "apply migration %d_%s", migration.Version, migration.Name)
+}
+
+// revert reverts the migration within a transaction. It is a no-op if the
+// migration has been reverted by a concurrent runner.
+func (m *Migrator) revert(ctx context.Context, migration Migration) error {
+	err := m.db.Transaction(ctx, func(tx norm.DB) error {
+		if err := m.lock(ctx, tx); err != nil {
+			return err
+		}
+
+		applied, err := m.isApplied(ctx, tx, migration.Version)
+		if err != nil {
+			return err
+		} else if !applied {
+			return nil
+		}
+
+		if err = migration.Down(ctx, tx); err != nil {
+			return err
+		}
+
+		_, err = tx.DeleteFrom(m.table).
+			Where("version = ?", migration.Version).
+			Exec(ctx)
+		return errors.Wrap(err, "delete migration record")
+	})
+	return errors.Wrapf(err, "revert migration %d_%s", migration.Version, migration.Name)
+}
+
+// find returns the migration with given version, or nil if not found.
+func (m *Migrator) find(version int64) *Migration {
+	return findMigration(m.migrations, version)
+}
+
+func findMigration(migrations []Migration, version int64) *Migration {
+	i := sort.Search(len(migrations), func(i int) bool {
+		return migrations[i].Version >= version
+	})
+	if i < len(migrations) && migrations[i].Version == version {
+		return &migrations[i]
+	}
+	return nil
+}
+
+// verify returns ErrChecksumMismatch if any applied migration has a different
+// checksum than its current one.
+func verify(migrations []Migration, records []record) error {
+	for _, r := range records {
+		migration := findMigration(migrations, r.Version)
+		if migration == nil || migration.checksum == "" {
+			continue
+		}
+
+		if migration.checksum != r.Checksum {
+			return errors.Wrapf(ErrChecksumMismatch, "migration %d_%s", migration.Version, migration.Name)
+		}
+	}
+	return nil
+}
+
+// planUp returns the pending migrations up to the given version in the
+// ascending order of versions. A negative version means no upper bound.
+func planUp(migrations []Migration, records []record, version int64) []Migration {
+	applied := make(map[int64]bool, len(records))
+	for _, r := range records {
+		applied[r.Version] = true
+	}
+
+	var pending []Migration
+	for _, migration := range migrations {
+		if version >= 0 && migration.Version > version {
+			break
+		}
+		if !applied[migration.Version] {
+			pending = append(pending, migration)
+		}
+	}
+	return pending
+}
+
+// planDown returns the last n applied migrations in the descending order of
+// versions.
+func planDown(migrations []Migration, records []record, n int) ([]Migration, error) {
+	if n > len(records) {
+		n = len(records)
+	}
+
+	reverts := make([]Migration, 0, n)
+	for i := len(records) - 1; i >= len(records)-n; i-- {
+		migration := findMigration(migrations, records[i].Version)
+		if migration == nil {
+			return nil, errors.Errorf("unknown applied migration %d_%s", records[i].Version, records[i].Name)
+		} else if migration.Down == nil {
+			return nil, errors.Errorf("irreversible migration %d_%s", migration.Version, migration.Name)
+		}
+		reverts = append(reverts, *migration)
+	}
+	return reverts, nil
+}
+
+// status returns the status of migrations in the ascending order of versions.
+func status(migrations []Migration, records []record) []Status {
+	byVersion := make(map[int64]record, len(records))
+	for _, r := range records {
+		byVersion[r.Version] = r
+	}
+
+	statuses := make([]Status, 0, len(migrations))
+	for _, migration := range migrations {
+		s := Status{
+			Version: migration.Version,
+			Name:    migration.Name,
+		}
+		if r, ok := byVersion[migration.Version]; ok {
+			s.Applied = true
+			s.AppliedAt = r.AppliedAt
+			s.Drifted = migration.checksum != "" && migration.checksum != r.Checksum
+			delete(byVersion, migration.Version)
+		}
+		statuses = append(statuses, s)
+	}
+
+	for _, r := range byVersion {
+		statuses = append(statuses, Status{
+			Version:   r.Version,
+			Name:      r.Name,
+			Applied:   true,
+			AppliedAt: r.AppliedAt,
+			Unknown:   true,
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+	return statuses
+}
@@ -0,0 +1,510 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"unknwon.dev/norm"
+	"unknwon.dev/norm/adapter"
+	"unknwon.dev/norm/internal/exql"
+	"unknwon.dev/norm/internal/sqlbuilder"
+)
+
+func noop(context.Context, norm.DB) error { return nil }
+
+func versions(migrations []Migration) []int64 {
+	vs := make([]int64, len(migrations))
+	for i := range migrations {
+		vs[i] = migrations[i].Version
+	}
+	return vs
+}
+
+func TestNew(t *testing.T) {
+	t.Run("sorted", func(t *testing.T) {
+		m, err := New(nil, []Migration{
+			{Version: 3, Up: noop},
+			{Version: 1, Up: noop},
+			{Version: 2, Up: noop},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1, 2, 3}, versions(m.migrations))
+		assert.Equal(t, DefaultTable, m.table)
+		assert.NotZero(t, m.lockKey)
+	})
+
+	t.Run("options", func(t *testing.T) {
+		m, err := New(nil, nil, Options{Table: "migrations", LockKey: 42})
+		require.NoError(t, err)
+		assert.Equal(t, "migrations", m.table)
+		assert.Equal(t, int64(42), m.lockKey)
+	})
+
+	tests := []struct {
+		name       string
+		migrations []Migration
+	}{
+		{
+			name:       "invalid version",
+			migrations: []Migration{{Version: 0, Up: noop}},
+		},
+		{
+			name:       "missing up",
+			migrations: []Migration{{Version: 1}},
+		},
+		{
+			name:       "duplicated version",
+			migrations: []Migration{{Version: 1, Up: noop}, {Version: 1, Up: noop}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := New(nil, test.migrations)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	migrations := []Migration{
+		{Version: 1, Up: noop, checksum: "a"},
+		{Version: 2, Up: noop},
+	}
+
+	t.Run("match", func(t *testing.T) {
+		err := verify(migrations, []record{{Version: 1, Checksum: "a"}, {Version: 2}, {Version: 3}})
+		assert.NoError(t, err)
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		err := verify(migrations, []record{{Version: 1, Checksum: "b"}})
+		assert.True(t, errors.Is(err, ErrChecksumMismatch))
+	})
+}
+
+func TestPlanUp(t *testing.T) {
+	migrations := []Migration{
+		{Version: 1, Up: noop},
+		{Version: 2, Up: noop},
+		{Version: 3, Up: noop},
+		{Version: 4, Up: noop},
+	}
+	records := []record{{Version: 1}, {Version: 3}}
+
+	assert.Equal(t, []int64{2, 4}, versions(planUp(migrations, records, -1)))
+	assert.Equal(t, []int64{2}, versions(planUp(migrations, records, 3)))
+	assert.Empty(t, planUp(migrations, records, 0))
+}
+
+func TestPlanDown(t *testing.T) {
+	migrations := []Migration{
+		{Version: 1, Up: noop, Down: noop},
+		{Version: 2, Up: noop, Down: noop},
+		{Version: 3, Up: noop},
+	}
+
+	t.Run("normal", func(t *testing.T) {
+		records := []record{{Version: 1}, {Version: 2}}
+
+		got, err := planDown(migrations, records, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{2}, versions(got))
+
+		got, err = planDown(migrations, records, 5)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{2, 1}, versions(got))
+	})
+
+	t.Run("irreversible", func(t *testing.T) {
+		_, err := planDown(migrations, []record{{Version: 1}, {Version: 3}}, 1)
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		_, err := planDown(migrations, []record{{Version: 1}, {Version: 9}}, 1)
+		assert.Error(t, err)
+	})
+}
+
+func TestStatus(t *testing.T) {
+	migrations := []Migration{
+		{Version: 1, Name: "create_users", Up: noop, checksum: "a"},
+		{Version: 2, Name: "add_email", Up: noop, checksum: "b"},
+		{Version: 4, Name: "add_name", Up: noop},
+	}
+	records := []record{
+		{Version: 1, Name: "create_users", Checksum: "a"},
+		{Version: 2, Name: "add_email", Checksum: "c"},
+		{Version: 3, Name: "removed"},
+	}
+
+	want := []Status{
+		{Version: 1, Name: "create_users", Applied: true},
+		{Version: 2, Name: "add_email", Applied: true, Drifted: true},
+		{Version: 3, Name: "removed", Applied: true, Unknown: true},
+		{Version: 4, Name: "add_name"},
+	}
+	assert.Equal(t, want, status(migrations, records))
+}
+
+// mockDB is a norm.DB that executes queries with the mock adapter, where
+// transactions run functions with the database itself.
+type mockDB struct {
+	norm.SQL
+	adapter *MockAdapter
+	txs     int
+}
+
+func (db *mockDB) Now() time.Time                       { return time.Now() }
+func (db *mockDB) Driver() norm.Driver                  { return nil }
+func (db *mockDB) Adapter() adapter.Adapter             { return db.adapter }
+func (db *mockDB) Close() error                         { return nil }
+func (db *mockDB) AfterCommit(fn func(context.Context)) { fn(context.Background()) }
+func (db *mockDB) AfterRollback(func(context.Context))  {}
+
+func (db *mockDB) Conn(_ context.Context, fn func(conn norm.DB) error) error {
+	return fn(db)
+}
+
+func (db *mockDB) Transaction(_ context.Context, fn func(tx norm.DB) error, _ ...*norm.TxOptions) error {
+	db.txs++
+	return fn(db)
+}
+
+func (db *mockDB) Begin(context.Context, ...*norm.TxOptions) (norm.Tx, error) {
+	return nil, errors.New("not supported")
+}
+
+// migrationsTable is the in-memory table of applied migrations that is backed
+// by the mockDB.
+type migrationsTable struct {
+	records map[int64]record
+	// missing indicates whether the table has not been created.
+	missing bool
+	// locks is the number of times the advisory lock has been acquired.
+	locks int
+	// onLock is called after the advisory lock is acquired.
+	onLock func(n int)
+}
+
+//go:generate go-mockgen --force unknwon.dev/norm/adapter -i Adapter -i Executor -i Rows -i Typer -o mock_adapter_test.go
+func newMockDB(t *testing.T, table *migrationsTable) *mockDB {
+	tmpl, err := exql.DefaultTemplate()
+	require.NoError(t, err)
+
+	executor := NewMockExecutor()
+	executor.ExecFunc.SetDefaultHook(func(_ context.Context, stmt *exql.Statement, args ...interface{}) (sql.Result, error) {
+		switch stmt.Type {
+		case exql.StatementSQL:
+			assert.Equal(t, "SELECT pg_advisory_xact_lock(?)", stmt.SQL)
+			table.locks++
+			if table.onLock != nil {
+				table.onLock(table.locks)
+			}
+		case exql.StatementCreateTable:
+			table.missing = false
+		case exql.StatementInsert:
+			version := args[0].(int64)
+			_, ok := table.records[version]
+			require.False(t, ok, "version %d has been recorded", version)
+			table.records[version] = record{Version: version, Name: args[1].(string), Checksum: args[2].(string)}
+		case exql.StatementDelete:
+			delete(table.records, args[0].(int64))
+		default:
+			t.Fatalf("unexpected statement type %v", stmt.Type)
+		}
+		return nil, nil
+	})
+	executor.QueryFunc.SetDefaultHook(func(_ context.Context, stmt *exql.Statement, args ...interface{}) (adapter.Rows, error) {
+		if stmt.Type == exql.StatementSQL {
+			assert.Equal(t, "SELECT to_regclass(?) IS NOT NULL", stmt.SQL)
+			assert.Equal(t, []interface{}{DefaultTable}, args)
+			rows := NewMockRows()
+			rows.NextFunc.PushReturn(true)
+			rows.ScanFunc.SetDefaultHook(func(dest ...interface{}) error {
+				*dest[0].(*bool) = !table.missing
+				return nil
+			})
+			return rows, nil
+		}
+		require.False(t, table.missing, "table has not been created")
+		require.Equal(t, exql.StatementSelect, stmt.Type)
+
+		var records []record
+		for _, r := range table.records {
+			if len(args) == 0 || args[0] == r.Version {
+				records = append(records, r)
+			}
+		}
+		sort.Slice(records, func(i, j int) bool {
+			return records[i].Version < records[j].Version
+		})
+
+		columns := []string{"version", "name", "checksum", "applied_at"}
+		if len(args) > 0 {
+			columns = columns[:1]
+		}
+		rows := NewMockRows()
+		rows.ColumnsFunc.SetDefaultReturn(columns, nil)
+		i := -1
+		rows.NextFunc.SetDefaultHook(func() bool {
+			i++
+			return i < len(records)
+		})
+		rows.ScanFunc.SetDefaultHook(func(dest ...interface{}) error {
+			values := []interface{}{records[i].Version, records[i].Name, records[i].Checksum, records[i].AppliedAt}
+			for j := range dest {
+				reflect.ValueOf(dest[j]).Elem().Set(reflect.ValueOf(values[j]))
+			}
+			return nil
+		})
+		return rows, nil
+	})
+
+	typer := NewMockTyper()
+	typer.ScannerFunc.SetDefaultHook(func(v interface{}) interface{} {
+		return v
+	})
+
+	adp := NewMockAdapter()
+	adp.ExecutorFunc.SetDefaultReturn(executor)
+	adp.TyperFunc.SetDefaultReturn(typer)
+	adp.FormatSQLFunc.SetDefaultHook(exql.StripWhitespace)
+	return &mockDB{
+		SQL:     sqlbuilder.New(adp, tmpl),
+		adapter: adp,
+	}
+}
+
+// tracer records the calls of migration functions.
+type tracer []string
+
+func (tr *tracer) fn(name string) Func {
+	return func(context.Context, norm.DB) error {
+		*tr = append(*tr, name)
+		return nil
+	}
+}
+
+func appliedVersions(table *migrationsTable) []int64 {
+	var vs []int64
+	for v := range table.records {
+		vs = append(vs, v)
+	}
+	sort.Slice(vs, func(i, j int) bool { return vs[i] < vs[j] })
+	return vs
+}
+
+func TestMigrator_Up(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("pending", func(t *testing.T) {
+		table := &migrationsTable{records: map[int64]record{1: {Version: 1, Name: "create_users", Checksum: "a"}}}
+		db := newMockDB(t, table)
+
+		var calls tracer
+		m, err := New(db, []Migration{
+			{Version: 1, Name: "create_users", Up: calls.fn("up 1"), checksum: "a"},
+			{Version: 2, Name: "add_email", Up: calls.fn("up 2"), checksum: "b"},
+			{Version: 3, Name: "add_name", Up: calls.fn("up 3")},
+		})
+		require.NoError(t, err)
+
+		err = m.Up(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, tracer{"up 2", "up 3"}, calls)
+		assert.Equal(t, []int64{1, 2, 3}, appliedVersions(table))
+		assert.Equal(t, "b", table.records[2].Checksum)
+		assert.Equal(t, "", table.records[3].Checksum)
+
+		// Creating the table and applying every migration hold the lock
+		assert.Equal(t, 3, db.txs)
+		assert.Equal(t, 3, table.locks)
+	})
+
+	t.Run("applied by a concurrent runner", func(t *testing.T) {
+		table := &migrationsTable{records: map[int64]record{}}
+		table.onLock = func(n int) {
+			// The first lock is for creating the table
+			if n == 2 {
+				table.records[1] = record{Version: 1, Name: "create_users"}
+			}
+		}
+		db := newMockDB(t, table)
+
+		var calls tracer
+		m, err := New(db, []Migration{
+			{Version: 1, Name: "create_users", Up: calls.fn("up 1")},
+			{Version: 2, Name: "add_email", Up: calls.fn("up 2")},
+		})
+		require.NoError(t, err)
+
+		err = m.Up(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, tracer{"up 2"}, calls)
+		assert.Equal(t, []int64{1, 2}, appliedVersions(table))
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		table := &migrationsTable{records: map[int64]record{1: {Version: 1, Name: "create_users", Checksum: "x"}}}
+		db := newMockDB(t, table)
+
+		var calls tracer
+		m, err := New(db, []Migration{
+			{Version: 1, Name: "create_users", Up: calls.fn("up 1"), checksum: "a"},
+			{Version: 2, Name: "add_email", Up: calls.fn("up 2")},
+		})
+		require.NoError(t, err)
+
+		err = m.Up(ctx)
+		assert.True(t, errors.Is(err, ErrChecksumMismatch))
+		assert.Empty(t, calls)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		table := &migrationsTable{records: map[int64]record{}}
+		db := newMockDB(t, table)
+
+		m, err := New(db, []Migration{
+			{
+				Version: 1,
+				Name:    "create_users",
+				Up: func(context.Context, norm.DB) error {
+					return errors.New("boom")
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		err = m.Up(ctx)
+		assert.EqualError(t, err, "apply migration 1_create_users: boom")
+		assert.Empty(t, table.records)
+	})
+}
+
+func TestMigrator_Down(t *testing.T) {
+	table := &migrationsTable{
+		records: map[int64]record{
+			1: {Version: 1, Name: "create_users"},
+			2: {Version: 2, Name: "add_email"},
+		},
+	}
+	db := newMockDB(t, table)
+
+	var calls tracer
+	m, err := New(db, []Migration{
+		{Version: 1, Name: "create_users", Up: calls.fn("up 1"), Down: calls.fn("down 1")},
+		{Version: 2, Name: "add_email", Up: calls.fn("up 2"), Down: calls.fn("down 2")},
+	})
+	require.NoError(t, err)
+
+	for _, n := range []int{0, -1} {
+		err = m.Down(context.Background(), n)
+		assert.EqualError(t, err, fmt.Sprintf("invalid number of migrations to revert: %d", n))
+	}
+	assert.Zero(t, table.locks)
+
+	err = m.Down(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, tracer{"down 2"}, calls)
+	assert.Equal(t, []int64{1}, appliedVersions(table))
+}
+
+func TestMigrator_Status(t *testing.T) {
+	ctx := context.Background()
+
+	migrations := func(calls *tracer) []Migration {
+		return []Migration{
+			{Version: 1, Name: "create_users", Up: calls.fn("up 1")},
+			{Version: 2, Name: "add_email", Up: calls.fn("up 2")},
+		}
+	}
+
+	t.Run("table not exist", func(t *testing.T) {
+		table := &migrationsTable{records: map[int64]record{}, missing: true}
+		db := newMockDB(t, table)
+
+		var calls tracer
+		m, err := New(db, migrations(&calls))
+		require.NoError(t, err)
+
+		got, err := m.Status(ctx)
+		require.NoError(t, err)
+		want := []Status{
+			{Version: 1, Name: "create_users"},
+			{Version: 2, Name: "add_email"},
+		}
+		assert.Equal(t, want, got)
+		assert.True(t, table.missing)
+		assert.Zero(t, db.txs)
+		assert.Zero(t, table.locks)
+	})
+
+	t.Run("applied", func(t *testing.T) {
+		table := &migrationsTable{records: map[int64]record{1: {Version: 1, Name: "create_users"}}}
+		db := newMockDB(t, table)
+
+		var calls tracer
+		m, err := New(db, migrations(&calls))
+		require.NoError(t, err)
+
+		got, err := m.Status(ctx)
+		require.NoError(t, err)
+		want := []Status{
+			{Version: 1, Name: "create_users", Applied: true},
+			{Version: 2, Name: "add_email"},
+		}
+		assert.Equal(t, want, got)
+		assert.Zero(t, db.txs)
+		assert.Zero(t, table.locks)
+		assert.Empty(t, calls)
+	})
+}
+
+func TestMigrator_To(t *testing.T) {
+	ctx := context.Background()
+	table := &migrationsTable{
+		records: map[int64]record{
+			1: {Version: 1, Name: "create_users"},
+			2: {Version: 2, Name: "add_email"},
+		},
+	}
+	db := newMockDB(t, table)
+
+	var calls tracer
+	m, err := New(db, []Migration{
+		{Version: 1, Name: "create_users", Up: calls.fn("up 1"), Down: calls.fn("down 1")},
+		{Version: 2, Name: "add_email", Up: calls.fn("up 2"), Down: calls.fn("down 2")},
+		{Version: 3, Name: "add_name", Up: calls.fn("up 3"), Down: calls.fn("down 3")},
+	})
+	require.NoError(t, err)
+
+	err = m.To(ctx, 9)
+	assert.EqualError(t, err, "unknown version 9")
+
+	err = m.To(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1}, appliedVersions(table))
+
+	err = m.To(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2, 3}, appliedVersions(table))
+
+	err = m.To(ctx, 0)
+	require.NoError(t, err)
+	assert.Empty(t, appliedVersions(table))
+	assert.Equal(t, tracer{"down 2", "up 2", "up 3", "down 3", "down 2", "down 1"}, calls)
+}
@@ -0,0 +1,133 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"unknwon.dev/norm"
+	"unknwon.dev/norm/internal/exql"
+)
+
+// Func is a function that runs a migration within a transaction.
+type Func func(ctx context.Context, tx norm.DB) error
+
+// Migration is a versioned schema migration. Migrations that are loaded by
+// FromFS are recorded with the checksums of their scripts, which are verified
+// against applied ones to detect drift. Migrations that are defined by Go
+// functions have no checksum and are never checked for drift.
+type Migration struct {
+	// Version is the unique version of the migration. Migrations are applied in
+	// the ascending order of versions.
+	Version int64
+	// Name is the human-readable name of the migration.
+	Name string
+	// Up is the function to apply the migration.
+	Up Func
+	// Down is the function to revert the migration. The migration is irreversible
+	// when it is nil.
+	Down Func
+
+	// checksum is the checksum of the migration script, it is empty for
+	// migrations that are defined by Go functions.
+	checksum string
+}
+
+// fileNamePattern matches file names like "0001_create_users.up.sql".
+var fileNamePattern = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// FromFS loads SQL migrations from the root of the file system. Migrations are
+// pairs of files named as "<version>_<name>.up.sql" and
+// "<version>_<name>.down.sql", where the down file is optional. Files that do
+// not follow the naming convention are ignored.
+//
+// Use fs.Sub to load migrations from a subdirectory:
+//
+//   //go:embed migrations/*.sql
+//   var files embed.FS
+//
+//   sub, _ := fs.Sub(files, "migrations")
+//   migrations, err := migrate.FromFS(sub)
+func FromFS(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, errors.Wrap(err, "read directory")
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		matches := fileNamePattern.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(matches[1], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse version of %q", entry.Name())
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{
+				Version: version,
+				Name:    matches[2],
+			}
+			byVersion[version] = m
+		} else if m.Name != matches[2] {
+			return nil, errors.Errorf("duplicated version %d: %q and %q", version, m.Name, matches[2])
+		}
+
+		p, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, errors.Wrapf(err, "read %q", entry.Name())
+		}
+
+		script := string(p)
+		if matches[3] == "up" {
+			m.Up = execScript(script)
+			m.checksum = checksum(p)
+		} else {
+			m.Down = execScript(script)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == nil {
+			return nil, errors.Errorf("missing up migration for version %d", m.Version)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// execScript returns a Func that executes the given SQL script.
+func execScript(script string) Func {
+	return func(ctx context.Context, tx norm.DB) error {
+		_, err := tx.Adapter().Executor().Exec(ctx, exql.RawSQL(script))
+		return err
+	}
+}
+
+// checksum returns the hex-encoded SHA-256 checksum of the given content.
+func checksum(p []byte) string {
+	sum := sha256.Sum256(p)
+	return hex.EncodeToString(sum[:])
+}
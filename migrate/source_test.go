@@ -0,0 +1,56 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrate
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromFS(t *testing.T) {
+	t.Run("normal", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"0002_add_email.up.sql":      {Data: []byte(`ALTER TABLE users ADD COLUMN email TEXT;`)},
+			"0001_create_users.up.sql":   {Data: []byte(`CREATE TABLE users (id BIGINT);`)},
+			"0001_create_users.down.sql": {Data: []byte(`DROP TABLE users;`)},
+			"README.md":                  {Data: []byte(`# Migrations`)},
+			"0003_nested/foo.up.sql":     {Data: []byte(`SELECT 1;`)},
+		}
+		got, err := FromFS(fsys)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+
+		assert.Equal(t, int64(1), got[0].Version)
+		assert.Equal(t, "create_users", got[0].Name)
+		assert.NotNil(t, got[0].Up)
+		assert.NotNil(t, got[0].Down)
+		assert.Equal(t, checksum([]byte(`CREATE TABLE users (id BIGINT);`)), got[0].checksum)
+
+		assert.Equal(t, int64(2), got[1].Version)
+		assert.Equal(t, "add_email", got[1].Name)
+		assert.NotNil(t, got[1].Up)
+		assert.Nil(t, got[1].Down)
+	})
+
+	t.Run("missing up", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"0001_create_users.down.sql": {Data: []byte(`DROP TABLE users;`)},
+		}
+		_, err := FromFS(fsys)
+		assert.Error(t, err)
+	})
+
+	t.Run("duplicated version", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"0001_create_users.up.sql":  {Data: []byte(`CREATE TABLE users (id BIGINT);`)},
+			"0001_create_emails.up.sql": {Data: []byte(`CREATE TABLE emails (id BIGINT);`)},
+		}
+		_, err := FromFS(fsys)
+		assert.Error(t, err)
+	})
+}
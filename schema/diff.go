@@ -0,0 +1,188 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package schema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DifferenceKind is the kind of a difference between two table definitions.
+type DifferenceKind uint8
+
+const (
+	_ = DifferenceKind(iota)
+
+	// DifferenceMissingTable indicates the table does not exist.
+	DifferenceMissingTable
+	// DifferenceMissingColumn indicates the column is wanted but does not exist.
+	DifferenceMissingColumn
+	// DifferenceExtraColumn indicates the column exists but is not wanted.
+	DifferenceExtraColumn
+	// DifferenceTypeMismatch indicates the column has a different data type.
+	DifferenceTypeMismatch
+	// DifferenceNullabilityMismatch indicates the column has a different
+	// nullability.
+	DifferenceNullabilityMismatch
+)
+
+// Difference is a difference between the wanted and the actual table
+// definitions.
+type Difference struct {
+	// Kind is the kind of the difference.
+	Kind DifferenceKind
+	// Table is the name of the table.
+	Table string
+	// Column is the name of the column, it is empty for DifferenceMissingTable.
+	Column string
+	// Want is the wanted column, it is nil for DifferenceMissingTable and
+	// DifferenceExtraColumn.
+	Want *Column
+	// Got is the actual column, it is nil for DifferenceMissingTable and
+	// DifferenceMissingColumn.
+	Got *Column
+}
+
+var _ fmt.Stringer = (*Difference)(nil)
+
+func (d Difference) String() string {
+	switch d.Kind {
+	case DifferenceMissingTable:
+		return fmt.Sprintf("table %q is missing", d.Table)
+	case DifferenceMissingColumn:
+		return fmt.Sprintf("table %q: column %q is missing", d.Table, d.Column)
+	case DifferenceExtraColumn:
+		return fmt.Sprintf("table %q: column %q is not wanted", d.Table, d.Column)
+	case DifferenceTypeMismatch:
+		return fmt.Sprintf("table %q: column %q has type %q but want %q", d.Table, d.Column, d.Got.Type, d.Want.Type)
+	case DifferenceNullabilityMismatch:
+		if d.Want.Nullable {
+			return fmt.Sprintf("table %q: column %q is NOT NULL but want nullable", d.Table, d.Column)
+		}
+		return fmt.Sprintf("table %q: column %q is nullable but want NOT NULL", d.Table, d.Column)
+	}
+	return fmt.Sprintf("table %q: unexpected difference kind %d", d.Table, d.Kind)
+}
+
+// Diff returns the differences of columns from the wanted table definition to
+// the actual one in the order of columns, followed by extra columns. The actual
+// table definition is nil when the table does not exist. The data types are
+// only compared when the wanted column has one, and are compared after
+// normalization, e.g. "int8" and "bigint" are considered the same.
+func Diff(want, got *Table) []Difference {
+	if got == nil {
+		return []Difference{
+			{
+				Kind:  DifferenceMissingTable,
+				Table: want.Name,
+			},
+		}
+	}
+
+	var diffs []Difference
+	for _, w := range want.Columns {
+		g := got.Column(w.Name)
+		if g == nil {
+			diffs = append(diffs, Difference{
+				Kind:   DifferenceMissingColumn,
+				Table:  want.Name,
+				Column: w.Name,
+				Want:   w,
+			})
+			continue
+		}
+
+		if w.Type != "" && NormalizeType(w.Type) != NormalizeType(g.Type) {
+			diffs = append(diffs, Difference{
+				Kind:   DifferenceTypeMismatch,
+				Table:  want.Name,
+				Column: w.Name,
+				Want:   w,
+				Got:    g,
+			})
+		}
+		if w.Nullable != g.Nullable {
+			diffs = append(diffs, Difference{
+				Kind:   DifferenceNullabilityMismatch,
+				Table:  want.Name,
+				Column: w.Name,
+				Want:   w,
+				Got:    g,
+			})
+		}
+	}
+
+	for _, g := range got.Columns {
+		if want.Column(g.Name) == nil {
+			diffs = append(diffs, Difference{
+				Kind:   DifferenceExtraColumn,
+				Table:  want.Name,
+				Column: g.Name,
+				Got:    g,
+			})
+		}
+	}
+	return diffs
+}
+
+// typeAliases maps aliases of PostgreSQL data types to the names reported by
+// the database.
+var typeAliases = map[string]string{
+	"int2":        "smallint",
+	"smallserial": "smallint",
+	"int":         "integer",
+	"int4":        "integer",
+	"serial":      "integer",
+	"int8":        "bigint",
+	"bigserial":   "bigint",
+	"float4":      "real",
+	"float8":      "double precision",
+	"decimal":     "numeric",
+	"bool":        "boolean",
+	"varchar":     "character varying",
+	"char":        "character",
+	"timestamp":   "timestamp without time zone",
+	"timestamptz": "timestamp with time zone",
+	"time":        "time without time zone",
+	"timetz":      "time with time zone",
+}
+
+// NormalizeType returns the data type in the form that is reported by the
+// database, e.g. "VARCHAR(255)" becomes "character varying(255)" and
+// "timestamptz(3)" becomes "timestamp(3) with time zone".
+func NormalizeType(typ string) string {
+	typ = strings.Join(strings.Fields(strings.ToLower(typ)), " ")
+
+	var array string
+	for strings.HasSuffix(typ, "[]") {
+		typ = strings.TrimSpace(strings.TrimSuffix(typ, "[]"))
+		array += "[]"
+	}
+
+	// Extract type modifiers, e.g. "(255)" of "varchar(255)"
+	var modifiers string
+	if start := strings.IndexByte(typ, '('); start >= 0 {
+		if end := strings.IndexByte(typ[start:], ')'); end >= 0 {
+			modifiers = strings.ReplaceAll(typ[start:start+end+1], " ", "")
+			typ = strings.Join(strings.Fields(typ[:start]+" "+typ[start+end+1:]), " ")
+		}
+	}
+
+	if alias, ok := typeAliases[typ]; ok {
+		typ = alias
+	}
+
+	if modifiers != "" {
+		// Time types have modifiers right after the first word, e.g.
+		// "timestamp(3) with time zone".
+		if strings.HasPrefix(typ, "timestamp ") || strings.HasPrefix(typ, "time ") {
+			i := strings.IndexByte(typ, ' ')
+			typ = typ[:i] + modifiers + typ[i:]
+		} else {
+			typ += modifiers
+		}
+	}
+	return typ + array
+}
@@ -0,0 +1,99 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	want := &Table{
+		Name: "users",
+		Columns: []*Column{
+			{Name: "id", Type: "int8"},
+			{Name: "name", Type: "varchar(255)"},
+			{Name: "email", Type: "text", Nullable: true},
+			{Name: "age", Type: "integer"},
+			{Name: "bio"},
+		},
+	}
+
+	t.Run("missing table", func(t *testing.T) {
+		got := Diff(want, nil)
+		assert.Equal(t, []Difference{{Kind: DifferenceMissingTable, Table: "users"}}, got)
+		assert.Equal(t, `table "users" is missing`, got[0].String())
+	})
+
+	t.Run("no difference", func(t *testing.T) {
+		got := Diff(want, &Table{
+			Name: "users",
+			Columns: []*Column{
+				{Name: "id", Type: "bigint"},
+				{Name: "name", Type: "character varying(255)"},
+				{Name: "email", Type: "text", Nullable: true},
+				{Name: "age", Type: "integer"},
+				{Name: "bio", Type: "text"},
+			},
+		})
+		assert.Empty(t, got)
+	})
+
+	actual := &Table{
+		Name: "users",
+		Columns: []*Column{
+			{Name: "id", Type: "bigint"},
+			{Name: "name", Type: "text"},
+			{Name: "email", Type: "text"},
+			{Name: "bio", Type: "text", Nullable: true},
+			{Name: "nickname", Type: "text"},
+		},
+	}
+	got := Diff(want, actual)
+	wantDiffs := []Difference{
+		{Kind: DifferenceTypeMismatch, Table: "users", Column: "name", Want: want.Columns[1], Got: actual.Columns[1]},
+		{Kind: DifferenceNullabilityMismatch, Table: "users", Column: "email", Want: want.Columns[2], Got: actual.Columns[2]},
+		{Kind: DifferenceMissingColumn, Table: "users", Column: "age", Want: want.Columns[3]},
+		{Kind: DifferenceNullabilityMismatch, Table: "users", Column: "bio", Want: want.Columns[4], Got: actual.Columns[3]},
+		{Kind: DifferenceExtraColumn, Table: "users", Column: "nickname", Got: actual.Columns[4]},
+	}
+	assert.Equal(t, wantDiffs, got)
+
+	wantStrings := []string{
+		`table "users": column "name" has type "text" but want "varchar(255)"`,
+		`table "users": column "email" is NOT NULL but want nullable`,
+		`table "users": column "age" is missing`,
+		`table "users": column "bio" is nullable but want NOT NULL`,
+		`table "users": column "nickname" is not wanted`,
+	}
+	for i := range got {
+		assert.Equal(t, wantStrings[i], got[i].String())
+	}
+}
+
+func TestNormalizeType(t *testing.T) {
+	tests := []struct {
+		typ  string
+		want string
+	}{
+		{typ: "bigint", want: "bigint"},
+		{typ: "INT8", want: "bigint"},
+		{typ: "serial", want: "integer"},
+		{typ: "VARCHAR(255)", want: "character varying(255)"},
+		{typ: "numeric(10, 2)", want: "numeric(10,2)"},
+		{typ: "timestamptz", want: "timestamp with time zone"},
+		{typ: "timestamptz(3)", want: "timestamp(3) with time zone"},
+		{typ: "timestamp(3) with time zone", want: "timestamp(3) with time zone"},
+		{typ: "timestamp", want: "timestamp without time zone"},
+		{typ: "int8[]", want: "bigint[]"},
+		{typ: "character  varying", want: "character varying"},
+	}
+	for _, test := range tests {
+		t.Run(test.typ, func(t *testing.T) {
+			assert.Equal(t, test.want, NormalizeType(test.typ))
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package schema provides a database-agnostic model of database schemas.
+package schema
+
+// Schema is a database schema (i.e. namespace) with its tables and enum types.
+type Schema struct {
+	// Name is the name of the schema.
+	Name string `json:"name"`
+	// Tables is the list of tables in the schema.
+	Tables []*Table `json:"tables"`
+	// Enums is the list of enum types in the schema.
+	Enums []*Enum `json:"enums,omitempty"`
+}
+
+// Table is a table in a database schema.
+type Table struct {
+	// Schema is the name of the schema that the table belongs to.
+	Schema string `json:"schema"`
+	// Name is the name of the table.
+	Name string `json:"name"`
+	// Columns is the list of columns in the order of their positions.
+	Columns []*Column `json:"columns"`
+	// PrimaryKey is the primary key of the table, it is nil when the table has no
+	// primary key.
+	PrimaryKey *PrimaryKey `json:"primary_key,omitempty"`
+	// ForeignKeys is the list of foreign keys of the table.
+	ForeignKeys []*ForeignKey `json:"foreign_keys,omitempty"`
+	// Indexes is the list of indexes of the table, including the ones that back
+	// primary keys and unique constraints.
+	Indexes []*Index `json:"indexes,omitempty"`
+}
+
+// Column returns the column with the given name, or nil if not found.
+func (t *Table) Column(name string) *Column {
+	for _, c := range t.Columns {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// Column is a column of a table.
+type Column struct {
+	// Name is the name of the column.
+	Name string `json:"name"`
+	// Type is the data type of the column as reported by the database, e.g.
+	// "bigint", "character varying(255)".
+	Type string `json:"type"`
+	// Nullable indicates whether the column accepts NULL values.
+	Nullable bool `json:"nullable"`
+	// Default is the expression of the column default value, it is nil when the
+	// column has no default value.
+	Default *string `json:"default,omitempty"`
+}
+
+// PrimaryKey is a primary key of a table.
+type PrimaryKey struct {
+	// Name is the name of the constraint.
+	Name string `json:"name"`
+	// Columns is the list of columns in the order of the key.
+	Columns []string `json:"columns"`
+}
+
+// ForeignKey is a foreign key of a table.
+type ForeignKey struct {
+	// Name is the name of the constraint.
+	Name string `json:"name"`
+	// Columns is the list of referencing columns.
+	Columns []string `json:"columns"`
+	// RefSchema is the name of the schema of the referenced table.
+	RefSchema string `json:"ref_schema"`
+	// RefTable is the name of the referenced table.
+	RefTable string `json:"ref_table"`
+	// RefColumns is the list of referenced columns, in the same order as the
+	// Columns.
+	RefColumns []string `json:"ref_columns"`
+	// OnUpdate is the action on update of the referenced rows, e.g. "CASCADE".
+	OnUpdate string `json:"on_update"`
+	// OnDelete is the action on deletion of the referenced rows, e.g. "CASCADE".
+	OnDelete string `json:"on_delete"`
+}
+
+// Index is an index of a table.
+type Index struct {
+	// Name is the name of the index.
+	Name string `json:"name"`
+	// Columns is the list of indexed columns or expressions.
+	Columns []string `json:"columns"`
+	// Unique indicates whether the index is unique.
+	Unique bool `json:"unique"`
+	// Primary indicates whether the index backs the primary key.
+	Primary bool `json:"primary"`
+	// Definition is the full definition of the index as reported by the database.
+	Definition string `json:"definition"`
+}
+
+// Enum is an enum type.
+type Enum struct {
+	// Schema is the name of the schema that the enum type belongs to.
+	Schema string `json:"schema"`
+	// Name is the name of the enum type.
+	Name string `json:"name"`
+	// Values is the list of values in the order of their sort positions.
+	Values []string `json:"values"`
+}
@@ -0,0 +1,112 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package schema
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+
+	"unknwon.dev/norm/internal/reflectx"
+)
+
+// MappingOptions contains options for mapping struct fields to columns, which
+// mirrors norm.MappingOptions and can be converted from it, e.g.
+// schema.MappingOptions(opts).
+type MappingOptions struct {
+	// TagName is the name of the struct field tag to look up column names and tag
+	// options. Default is "db".
+	TagName string
+	// NameFunc converts the Go field name to the column name for fields without a
+	// column name in their tags. Default is to use the field name as-is.
+	NameFunc func(field string) string
+}
+
+// FromStruct returns the table definition with given name that is described by
+// the `db` tags (or the tag of MappingOptions.TagName) of the struct. In
+// addition to the column name, the following tag options are recognized:
+//
+//   - type=<data type>: the data type of the column, e.g. "type=numeric(10,2)".
+//     Columns without a data type are not checked for type mismatches.
+//   - pk: the column is part of the primary key, implies "notnull".
+//   - notnull: the column does not accept NULL values.
+//   - null: the column accepts NULL values.
+//
+// Without explicit nullability options, a column is nullable when its Go type
+// is a pointer, slice, map, interface or a struct that implements sql.Scanner
+// (e.g. sql.NullString).
+//
+//   type User struct {
+//       ID    int64   `db:"id,type=bigint,pk"`
+//       Name  string  `db:"name,type=text"`
+//       Email *string `db:"email,type=character varying(255)"`
+//   }
+func FromStruct(name string, v interface{}, opts ...MappingOptions) (*Table, error) {
+	if v == nil {
+		return nil, errors.New("expect a struct but got nil")
+	}
+
+	t := reflectx.Deref(reflect.TypeOf(v))
+	if t.Kind() != reflect.Struct {
+		return nil, errors.Errorf("expect a struct but got %T", v)
+	}
+
+	var opt MappingOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.TagName == "" {
+		opt.TagName = "db"
+	}
+
+	table := &Table{
+		Name: name,
+	}
+	var primaryKey []string
+	fields := reflectx.NewMapperFunc(opt.TagName, opt.NameFunc).TypeMap(t)
+	for _, fi := range fields.Index {
+		if fi.Embedded || fi.Name == "" || fields.Paths[fi.Path] != fi || !reflectx.IsColumn(fi) {
+			continue
+		}
+
+		c := &Column{
+			Name:     fi.Path,
+			Type:     fi.Options["type"],
+			Nullable: isNullable(fi.Field.Type),
+		}
+		if _, ok := fi.Options["null"]; ok {
+			c.Nullable = true
+		}
+		if _, ok := fi.Options["notnull"]; ok {
+			c.Nullable = false
+		}
+		if _, ok := fi.Options["pk"]; ok {
+			c.Nullable = false
+			primaryKey = append(primaryKey, c.Name)
+		}
+		table.Columns = append(table.Columns, c)
+	}
+
+	if len(table.Columns) == 0 {
+		return nil, errors.Errorf("no column is found in %T", v)
+	}
+	if len(primaryKey) > 0 {
+		table.PrimaryKey = &PrimaryKey{
+			Columns: primaryKey,
+		}
+	}
+	return table, nil
+}
+
+// isNullable returns true if the Go type is able to represent NULL values.
+func isNullable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+		return true
+	case reflect.Struct:
+		return reflectx.IsScanner(t)
+	}
+	return false
+}
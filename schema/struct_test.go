@@ -0,0 +1,90 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package schema
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromStruct(t *testing.T) {
+	type Timestamps struct {
+		CreatedAt time.Time  `db:"created_at,type=timestamptz"`
+		DeletedAt *time.Time `db:"deleted_at,type=timestamptz"`
+	}
+	type Order struct {
+		ID       int64          `db:"id,type=bigint,pk"`
+		Price    string         `db:"price,type=numeric(10,2)"`
+		Note     sql.NullString `db:"note"`
+		Tags     []string       `db:"tags,type=text[],notnull"`
+		Legacy   int            `db:"legacy,null"`
+		Ignored  string         `db:"-"`
+		internal string
+		Timestamps
+	}
+
+	got, err := FromStruct("orders", &Order{})
+	require.NoError(t, err)
+
+	want := &Table{
+		Name: "orders",
+		Columns: []*Column{
+			{Name: "id", Type: "bigint", Nullable: false},
+			{Name: "price", Type: "numeric(10,2)", Nullable: false},
+			{Name: "note", Nullable: true},
+			{Name: "tags", Type: "text[]", Nullable: false},
+			{Name: "legacy", Nullable: true},
+			{Name: "created_at", Type: "timestamptz", Nullable: false},
+			{Name: "deleted_at", Type: "timestamptz", Nullable: true},
+		},
+		PrimaryKey: &PrimaryKey{
+			Columns: []string{"id"},
+		},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestFromStruct_MappingOptions(t *testing.T) {
+	type User struct {
+		ID        int64  `sql:"id,type=bigint,pk"`
+		FirstName string `sql:",type=text"`
+		Nickname  *string
+	}
+
+	got, err := FromStruct("users", User{}, MappingOptions{
+		TagName:  "sql",
+		NameFunc: strings.ToLower,
+	})
+	require.NoError(t, err)
+
+	want := &Table{
+		Name: "users",
+		Columns: []*Column{
+			{Name: "id", Type: "bigint", Nullable: false},
+			{Name: "firstname", Type: "text", Nullable: false},
+			{Name: "nickname", Nullable: true},
+		},
+		PrimaryKey: &PrimaryKey{
+			Columns: []string{"id"},
+		},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestFromStruct_Errors(t *testing.T) {
+	_, err := FromStruct("users", nil)
+	assert.Error(t, err)
+
+	_, err = FromStruct("users", "string")
+	assert.Error(t, err)
+
+	_, err = FromStruct("users", struct{ name string }{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,68 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package schemacheck
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+
+	"unknwon.dev/norm"
+	"unknwon.dev/norm/adapter/postgres"
+)
+
+// ErrDrift is returned by Run when any table has differences.
+var ErrDrift = errors.New("schema drift detected")
+
+// Run checks the Go structs of tables against the given schema of the
+// PostgreSQL database, and writes every difference to the writer. When printSQL
+// is true, it additionally writes the SQL statements to migrate the database.
+// It returns ErrDrift when any table has differences, see the schemacheck
+// command for running it in CI.
+func Run(ctx context.Context, w io.Writer, dsn, schemaName string, printSQL bool, tables map[string]interface{}, opts ...Options) error {
+	var opt Options
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	db, err := postgres.Open(dsn, postgres.OpenOptions{Mapping: opt.Mapping})
+	if err != nil {
+		return errors.Wrap(err, "open database")
+	}
+	defer func() { _ = db.Close() }()
+
+	results, err := Check(ctx, db, schemaName, tables, opt)
+	if err != nil {
+		return errors.Wrap(err, "check")
+	}
+	if len(results) == 0 {
+		return nil
+	}
+	return report(w, db, results, printSQL)
+}
+
+// report writes the differences and optionally the SQL statements to migrate
+// the database to the writer. It returns ErrDrift when there is no other error.
+func report(w io.Writer, q norm.SQL, results []Result, printSQL bool) error {
+	for _, r := range results {
+		for _, d := range r.Differences {
+			_, _ = fmt.Fprintln(w, d.String())
+		}
+	}
+
+	if printSQL {
+		_, _ = fmt.Fprintln(w)
+		for _, r := range results {
+			query, err := MigrationSQL(q, r)
+			if err != nil {
+				return errors.Wrapf(err, "generate migration SQL for table %q", r.Want.Name)
+			}
+			_, _ = fmt.Fprintf(w, "%s;\n", query)
+		}
+	}
+	return ErrDrift
+}
@@ -0,0 +1,158 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package schemacheck detects drift between the Go structs of tables and the
+// live database schema.
+package schemacheck
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"unknwon.dev/norm"
+	"unknwon.dev/norm/schema"
+)
+
+// Result is the differences of a table between its Go struct and the database.
+type Result struct {
+	// Want is the table definition that is derived from the Go struct.
+	Want *schema.Table
+	// Differences is the list of differences from the Go struct to the database.
+	Differences []schema.Difference
+}
+
+// Options contains options for checking tables.
+type Options struct {
+	// Mapping contains options for mapping struct fields to columns, which should
+	// be the same as the one used to open the database.
+	Mapping norm.MappingOptions
+}
+
+// Check compares the Go structs of tables with the tables in the given schema
+// of the database, and returns results of the tables that have differences in
+// the ascending order of table names. The tables is a map of table names to
+// their Go structs, see schema.FromStruct for the recognized tag options:
+//
+//   results, err := schemacheck.Check(ctx, db, "public", map[string]interface{}{
+//       "users":  User{},
+//       "emails": Email{},
+//   })
+func Check(ctx context.Context, db norm.DB, schemaName string, tables map[string]interface{}, opts ...Options) ([]Result, error) {
+	var opt Options
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	names := make([]string, 0, len(tables))
+	for name := range tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	introspector := db.Adapter().Introspector()
+	existing, err := introspector.Tables(ctx, schemaName)
+	if err != nil {
+		return nil, errors.Wrap(err, "list tables")
+	}
+	exists := make(map[string]bool, len(existing))
+	for _, name := range existing {
+		exists[name] = true
+	}
+
+	var results []Result
+	for _, name := range names {
+		want, err := schema.FromStruct(name, tables[name], schema.MappingOptions(opt.Mapping))
+		if err != nil {
+			return nil, errors.Wrapf(err, "table %q", name)
+		}
+		want.Schema = schemaName
+
+		var got *schema.Table
+		if exists[name] {
+			got, err = introspector.Table(ctx, schemaName, name)
+			if err != nil {
+				return nil, errors.Wrapf(err, "inspect table %q", name)
+			}
+		}
+
+		diffs := schema.Diff(want, got)
+		if len(diffs) > 0 {
+			results = append(results, Result{
+				Want:        want,
+				Differences: diffs,
+			})
+		}
+	}
+	return results, nil
+}
+
+// MigrationSQL returns the SQL statement to migrate the table in the database
+// to match its Go struct. It creates the table when it is missing, otherwise
+// it alters the table for every difference, including dropping extra columns.
+// The statement is meant to be reviewed before being applied, e.g. changing
+// data types may need a USING clause and adding NOT NULL columns to non-empty
+// tables needs a default value.
+func MigrationSQL(q norm.SQL, r Result) (string, error) {
+	table := r.Want.Name
+	if r.Want.Schema != "" && r.Want.Schema != "public" {
+		table = r.Want.Schema + "." + r.Want.Name
+	}
+
+	for _, d := range r.Differences {
+		if d.Kind == schema.DifferenceMissingTable {
+			return createTableSQL(q, table, r.Want)
+		}
+	}
+
+	alterer := q.AlterTable(table)
+	for _, d := range r.Differences {
+		switch d.Kind {
+		case schema.DifferenceMissingColumn:
+			if d.Want.Type == "" {
+				return "", errors.Errorf("column %q: unknown data type", d.Column)
+			}
+			alterer = alterer.AddColumn(norm.ColumnDefinition{
+				Name:    d.Column,
+				Type:    d.Want.Type,
+				NotNull: !d.Want.Nullable,
+			})
+		case schema.DifferenceExtraColumn:
+			alterer = alterer.DropColumn(d.Column)
+		case schema.DifferenceTypeMismatch:
+			alterer = alterer.AlterColumnType(d.Column, d.Want.Type)
+		case schema.DifferenceNullabilityMismatch:
+			if d.Want.Nullable {
+				alterer = alterer.DropNotNull(d.Column)
+			} else {
+				alterer = alterer.SetNotNull(d.Column)
+			}
+		default:
+			return "", errors.Errorf("unexpected difference kind %d", d.Kind)
+		}
+	}
+	return alterer.String(), nil
+}
+
+// createTableSQL returns the SQL statement to create the table.
+func createTableSQL(q norm.SQL, table string, want *schema.Table) (string, error) {
+	defs := make([]norm.ColumnDefinition, 0, len(want.Columns))
+	for _, c := range want.Columns {
+		if c.Type == "" {
+			return "", errors.Errorf("column %q: unknown data type", c.Name)
+		}
+		defs = append(defs, norm.ColumnDefinition{
+			Name:    c.Name,
+			Type:    c.Type,
+			NotNull: !c.Nullable,
+		})
+	}
+
+	creator := q.CreateTable(table).Columns(defs...)
+	if want.PrimaryKey != nil {
+		creator = creator.PrimaryKey(want.PrimaryKey.Name, want.PrimaryKey.Columns...)
+	}
+	return creator.String(), nil
+}
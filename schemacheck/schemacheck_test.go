@@ -0,0 +1,131 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package schemacheck
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"unknwon.dev/norm"
+	"unknwon.dev/norm/adapter"
+	"unknwon.dev/norm/internal/exql"
+	"unknwon.dev/norm/internal/sqlbuilder"
+	"unknwon.dev/norm/schema"
+)
+
+// formatAdapter is an adapter.Adapter that only formats SQL.
+type formatAdapter struct {
+	adapter.Adapter
+}
+
+func (formatAdapter) FormatSQL(sql string) string {
+	return exql.StripWhitespace(sql)
+}
+
+func newSQL(t *testing.T) norm.SQL {
+	tmpl, err := exql.DefaultTemplate()
+	require.NoError(t, err)
+	return sqlbuilder.New(formatAdapter{}, tmpl)
+}
+
+type user struct {
+	ID       int64   `db:"id,type=bigint,pk"`
+	Name     string  `db:"name,type=text"`
+	Nickname *string `db:"nickname,type=varchar(64)"`
+}
+
+func TestMigrationSQL(t *testing.T) {
+	q := newSQL(t)
+	want, err := schema.FromStruct("users", user{})
+	require.NoError(t, err)
+
+	t.Run("missing table", func(t *testing.T) {
+		got, err := MigrationSQL(q, Result{
+			Want:        want,
+			Differences: schema.Diff(want, nil),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, `CREATE TABLE "users" ("id" bigint NOT NULL, "name" text NOT NULL, "nickname" varchar(64), PRIMARY KEY ("id"))`, got)
+	})
+
+	t.Run("alter table", func(t *testing.T) {
+		got, err := MigrationSQL(q, Result{
+			Want: want,
+			Differences: schema.Diff(want, &schema.Table{
+				Name: "users",
+				Columns: []*schema.Column{
+					{Name: "id", Type: "integer"},
+					{Name: "name", Type: "text", Nullable: true},
+					{Name: "email", Type: "text"},
+				},
+			}),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, `ALTER TABLE "users" ALTER COLUMN "id" TYPE bigint, ALTER COLUMN "name" SET NOT NULL, ADD COLUMN "nickname" varchar(64), DROP COLUMN "email"`, got)
+	})
+
+	t.Run("schema-qualified", func(t *testing.T) {
+		want := *want
+		want.Schema = "auth"
+		got, err := MigrationSQL(q, Result{
+			Want: &want,
+			Differences: []schema.Difference{
+				{Kind: schema.DifferenceNullabilityMismatch, Table: "users", Column: "nickname", Want: want.Columns[2]},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, `ALTER TABLE "auth"."users" ALTER COLUMN "nickname" DROP NOT NULL`, got)
+	})
+
+	t.Run("unknown data type", func(t *testing.T) {
+		want := &schema.Table{
+			Name:    "users",
+			Columns: []*schema.Column{{Name: "id"}},
+		}
+		_, err := MigrationSQL(q, Result{
+			Want:        want,
+			Differences: schema.Diff(want, nil),
+		})
+		assert.Error(t, err)
+
+		_, err = MigrationSQL(q, Result{
+			Want:        want,
+			Differences: schema.Diff(want, &schema.Table{Name: "users"}),
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestReport(t *testing.T) {
+	q := newSQL(t)
+	want, err := schema.FromStruct("users", user{})
+	require.NoError(t, err)
+
+	results := []Result{
+		{
+			Want: want,
+			Differences: schema.Diff(want, &schema.Table{
+				Name: "users",
+				Columns: []*schema.Column{
+					{Name: "id", Type: "bigint"},
+					{Name: "name", Type: "text"},
+				},
+			}),
+		},
+	}
+
+	var buf bytes.Buffer
+	err = report(&buf, q, results, true)
+	assert.Equal(t, ErrDrift, err)
+
+	wantOutput := `table "users": column "nickname" is missing
+
+ALTER TABLE "users" ADD COLUMN "nickname" varchar(64);
+`
+	assert.Equal(t, wantOutput, buf.String())
+}
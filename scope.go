@@ -0,0 +1,41 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package norm
+
+import (
+	"context"
+	"errors"
+)
+
+// Scope is a default condition on a column of the given tables, whose value is
+// read from the context of every query, e.g. to isolate rows of tenants:
+//
+//   type tenantKey struct{}
+//
+//   norm.Scope{
+//       Tables: []string{"projects", "issues"},
+//       Column: "tenant_id",
+//       Value: func(ctx context.Context) (interface{}, bool) {
+//           id, ok := ctx.Value(tenantKey{}).(int64)
+//           return id, ok
+//       },
+//   }
+//
+// The condition (i.e. `tenant_id = ?`) is added to queries of Selector on the
+// tables in the FROM and JOIN clauses, and queries of Updater and Deleter on
+// the tables. Inserter sets the column to the value unless it is specified.
+type Scope struct {
+	// Tables is the list of tables that the scope applies to.
+	Tables []string
+	// Column is the name of the column.
+	Column string
+	// Value returns the value of the column from the context. Queries fail with
+	// ErrMissingScopeValue when the value is missing, i.e. ok is false.
+	Value func(ctx context.Context) (value interface{}, ok bool)
+}
+
+// ErrMissingScopeValue is returned when executing a query on tables of a Scope
+// whose value is missing from the context. Use errors.Is to check.
+var ErrMissingScopeValue = errors.New("missing scope value")
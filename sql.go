@@ -49,8 +49,71 @@ type SQL interface {
 	// Example:
 	//
 	//   q := db.DeleteFrom("users").Where(...)
+	//
+	// Rows of a soft-deletable table are marked as deleted by setting the
+	// "deleted_at" column to the current time, see Deleter.HardDelete.
 	DeleteFrom(table string) Deleter
-	// AlterTable() Alter
-	// Create() Creator
-	// Drop() Dropper
+
+	// CreateTable creates a TableCreator targeted at the given table.
+	//
+	// Example:
+	//
+	//   q := db.CreateTable("users").IfNotExists().Columns(...)
+	CreateTable(table string) TableCreator
+	// AlterTable creates a TableAlterer targeted at the given table.
+	//
+	// Example:
+	//
+	//   q := db.AlterTable("users").AddColumn(...).DropColumn("nickname")
+	AlterTable(table string) TableAlterer
+	// DropTable creates a TableDropper targeted at the given tables.
+	//
+	// Example:
+	//
+	//   q := db.DropTable("users", "emails").IfExists()
+	DropTable(tables ...string) TableDropper
+	// DropDatabase creates a DatabaseDropper targeted at the given database.
+	//
+	// Example:
+	//
+	//   q := db.DropDatabase("norm").IfExists()
+	DropDatabase(database string) DatabaseDropper
+	// Truncate creates a Truncater targeted at the given tables.
+	//
+	// Example:
+	//
+	//   q := db.Truncate("users", "emails").RestartIdentity()
+	Truncate(tables ...string) Truncater
+	// CreateIndex creates an IndexCreator with the given index name.
+	//
+	// Example:
+	//
+	//   q := db.CreateIndex("users_email_idx").On("users").Columns("email").Unique()
+	CreateIndex(name string) IndexCreator
+	// DropIndex creates an IndexDropper targeted at the given index.
+	//
+	// Example:
+	//
+	//   q := db.DropIndex("users_email_idx").IfExists()
+	DropIndex(name string) IndexDropper
+	// CreateView creates a ViewCreator with the given view name.
+	//
+	// Example:
+	//
+	//   q := db.CreateView("active_users").OrReplace().As(...)
+	CreateView(name string) ViewCreator
+	// CreateMaterializedView creates a ViewCreator with the given materialized
+	// view name.
+	//
+	// Example:
+	//
+	//   q := db.CreateMaterializedView("user_stats").IfNotExists().As(...)
+	CreateMaterializedView(name string) ViewCreator
+	// RefreshMaterializedView creates a MaterializedViewRefresher targeted at the
+	// given materialized view.
+	//
+	// Example:
+	//
+	//   q := db.RefreshMaterializedView("user_stats").Concurrently()
+	RefreshMaterializedView(name string) MaterializedViewRefresher
 }
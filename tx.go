@@ -0,0 +1,62 @@
+// Copyright 2022 Joe Chen. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package norm
+
+import (
+	"context"
+)
+
+// txContextKey is the key of the transaction in contexts, which is keyed by
+// the database handle that the transaction is started from.
+type txContextKey struct {
+	db DB
+}
+
+// WithTx returns a copy of the context that carries the transaction that is
+// started from the database handle. Queries of builders started from the same
+// database handle are executed within the transaction when they are executed
+// with the returned context, while builders of other database handles are not
+// affected.
+func WithTx(ctx context.Context, db, tx DB) context.Context {
+	return context.WithValue(ctx, txContextKey{db: db}, tx)
+}
+
+// TxFromContext returns the transaction that is started from the database
+// handle and carried by the context, see WithTx and TransactionContext for
+// details.
+func TxFromContext(ctx context.Context, db DB) (tx DB, ok bool) {
+	tx, ok = ctx.Value(txContextKey{db: db}).(DB)
+	return tx, ok
+}
+
+// TransactionContext runs the given function in a transaction like
+// Transactor.Transaction, but passes the transaction via the context, so that
+// code which only receives the context runs within the transaction, e.g.:
+//
+//   norm.TransactionContext(ctx, db, func(ctx context.Context) error {
+//       // Executed within the transaction
+//       _, err := db.InsertInto("users").Columns("name").Values("alice").Exec(ctx)
+//       ...
+//       tx, _ := norm.TxFromContext(ctx, db)
+//       tx.AfterCommit(...)
+//       ...
+//   })
+//
+// A nested transaction is started when the context already carries a
+// transaction that is started from the given database handle.
+//
+// It is a separate function rather than an option of TxOptions because the
+// function of Transactor.Transaction does not receive a context, thus there is
+// no way to hand it the context that carries the transaction. Options are
+// passed to Transactor.Transaction as is.
+func TransactionContext(ctx context.Context, db DB, fn func(ctx context.Context) error, opts ...*TxOptions) error {
+	root := db
+	if tx, ok := TxFromContext(ctx, root); ok {
+		db = tx
+	}
+	return db.Transaction(ctx, func(tx DB) error {
+		return fn(WithTx(ctx, root, tx))
+	}, opts...)
+}